@@ -5,9 +5,16 @@ package anime
 
 import (
 	"context"
+	"fmt"
+	"iter"
+	"time"
 
+	"github.com/dst3v3n/api-anime/internal/adapters/cache"
+	scraper "github.com/dst3v3n/api-anime/internal/adapters/scrapers/animeflv"
+	"github.com/dst3v3n/api-anime/internal/config"
 	"github.com/dst3v3n/api-anime/internal/domain/dto"
 	"github.com/dst3v3n/api-anime/internal/domain/services/animeflv"
+	"github.com/dst3v3n/api-anime/internal/ports"
 )
 
 // AnimeFlv es la fachada principal que expone públicamente todos los servicios de anime.
@@ -18,11 +25,104 @@ type AnimeFlv struct {
 }
 
 // NewAnimeFlv crea una nueva instancia del servicio público de AnimeFlv.
-// Inicializa el servicio interno con todas sus dependencias (scraper, caché, etc.).
+// Inicializa el servicio interno con todas sus dependencias (scraper, caché, etc.)
+// a partir del singleton global de configuración.
+//
+// Deprecated: usa New, que acepta dependencias inyectadas y retorna un error en lugar de entrar en pánico.
 func NewAnimeFlv() *AnimeFlv {
-	return &AnimeFlv{
-		service: animeflv.NewAnimeflvService(),
+	afv, err := New(WithConfig(config.MustGetConfig()))
+	if err != nil {
+		panic(err)
+	}
+	return afv
+}
+
+// New crea la fachada AnimeFlv con las dependencias indicadas mediante opciones.
+// Las dependencias no proporcionadas se construyen con valores por defecto: configuración
+// por defecto, logger derivado de la configuración, scraper HTTP de AnimeFlv y, si el caché
+// está habilitado, una conexión a Valkey. Nunca entra en pánico; cualquier fallo se retorna como error.
+func New(opts ...Option) (*AnimeFlv, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.config == nil {
+		o.config = config.NewConfigWithDefaults()
+	}
+	if err := o.config.Validate(); err != nil {
+		return nil, fmt.Errorf("configuración inválida: %w", err)
+	}
+
+	if o.logger == nil {
+		logger := o.config.Logging()
+		o.logger = &logger
+	}
+
+	// onClose libera únicamente los recursos creados aquí; los inyectados pertenecen al consumidor.
+	var onClose []func()
+
+	if o.scraper == nil {
+		httpClient := o.httpClient
+		if httpClient == nil {
+			httpClient = scraper.NewDefaultHTTPClient()
+			onClose = append(onClose, httpClient.CloseIdleConnections)
+		}
+		o.scraper = scraper.NewClientWithConfig(httpClient, o.config)
 	}
+
+	if o.config.EnableCache && o.cache == nil {
+		client, err := cache.NewValkeyClient(o.config)
+		if err != nil {
+			for _, release := range onClose {
+				release()
+			}
+			return nil, fmt.Errorf("error al conectar con Valkey: %w", err)
+		}
+		o.cache = cache.NewValkeyCacheWithConfig(client, o.config)
+		onClose = append(onClose, client.Close)
+	}
+
+	// Si la construcción falla, NewAnimeflvServiceWith libera los recursos de onClose.
+	service, err := animeflv.NewAnimeflvServiceWith(animeflv.Dependencies{
+		Scraper:     o.scraper,
+		Cache:       o.cache,
+		Config:      o.config,
+		Logger:      *o.logger,
+		OnClose:     onClose,
+		Middlewares: o.middlewares,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AnimeFlv{
+		service: service,
+	}, nil
+}
+
+// CircuitState retorna el estado del circuit breaker que protege al sitio: dto.CircuitClosed,
+// dto.CircuitOpen o dto.CircuitHalfOpen. Mientras está abierto, las operaciones se sirven desde
+// las copias de respaldo del caché (si el caché está habilitado) o retornan ErrCircuitOpen.
+// SearchAnime y Browse con filtros no guardan copia de respaldo y siempre retornan ErrCircuitOpen
+// si la página no está en caché.
+func (s *AnimeFlv) CircuitState() dto.CircuitState {
+	return s.service.CircuitState()
+}
+
+// RateLimits retorna el estado del límite de peticiones de cada clase de operación (búsquedas,
+// fichas, episodios y página principal): el ritmo configurado y el efectivo, que baja tras un
+// 429 y se recupera gradualmente. Útil para exportar métricas; retorna nil con un scraper inyectado.
+func (s *AnimeFlv) RateLimits() []dto.RateLimitState {
+	return s.service.RateLimits()
+}
+
+// Close cierra la fachada de forma ordenada: espera a que terminen los scrapes en curso
+// y sus escrituras en caché, y cierra la conexión a Valkey y las conexiones HTTP creadas por New.
+// Las operaciones invocadas después de Close retornan ErrClosed. Si ctx expira antes de drenar,
+// las operaciones pendientes se cancelan y se retorna el error del contexto.
+func (s *AnimeFlv) Close(ctx context.Context) error {
+	return s.service.Close(ctx)
 }
 
 // SearchAnime busca animes por nombre con soporte de paginación.
@@ -31,22 +131,73 @@ func (s *AnimeFlv) SearchAnime(ctx context.Context, anime string, page uint) (dt
 	return s.service.SearchAnime(ctx, anime, page)
 }
 
+// SearchIter recorre todos los resultados de una búsqueda sin manejar la paginación.
+// Las páginas se solicitan de forma perezosa (respetando el rate limiter y el caché)
+// y la iteración se detiene en cuanto el consumidor hace break o tras el primer error.
+//
+//	for anime, err := range service.SearchIter(ctx, "naruto") {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(anime.Title)
+//	}
+func (s *AnimeFlv) SearchIter(ctx context.Context, query string) iter.Seq2[dto.AnimeStruct, error] {
+	return s.service.SearchIter(ctx, query)
+}
+
 // Search obtiene todos los animes disponibles sin filtros de búsqueda.
 // Delega la operación al servicio interno de búsqueda.
 func (s *AnimeFlv) Search(ctx context.Context) (dto.AnimeResponse, error) {
 	return s.service.Search(ctx)
 }
 
+// Browse obtiene una página del catálogo filtrada por género, año, tipo, estado y orden.
+// Los campos vacíos del filtro no filtran; page 0 equivale a la primera página.
+func (s *AnimeFlv) Browse(ctx context.Context, filter dto.BrowseFilter, page uint) (dto.AnimeResponse, error) {
+	return s.service.Browse(ctx, filter, page)
+}
+
+// Genres obtiene los géneros disponibles: el slug que acepta BrowseFilter.Genres
+// y el nombre visible que aparece en AnimeInfoResponse.Genres.
+func (s *AnimeFlv) Genres(ctx context.Context) ([]dto.Genre, error) {
+	return s.service.Genres(ctx)
+}
+
 // AnimeInfo obtiene información detallada de un anime por su ID.
 // Delega la operación al servicio interno de detalles.
 func (s *AnimeFlv) AnimeInfo(ctx context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
 	return s.service.AnimeInfo(ctx, idAnime)
 }
 
+// AnimeInfoMany obtiene la información de varios animes de forma concurrente,
+// con un máximo de opts.Concurrency consultas simultáneas bajo el límite de peticiones de las fichas de anime.
+// Retorna los resultados exitosos y, por separado, el error de cada ID que falló,
+// de modo que un ID inválido no hace fallar el lote completo.
+func (s *AnimeFlv) AnimeInfoMany(ctx context.Context, ids []string, opts dto.BatchOptions) (map[string]dto.AnimeInfoResponse, map[string]error) {
+	return s.service.AnimeInfoMany(ctx, ids, opts)
+}
+
 // Links obtiene los enlaces de reproducción para un episodio específico.
-// Retorna información de múltiples servidores de video con URLs y códigos de embed.
-func (s *AnimeFlv) Links(ctx context.Context, idAnime string, episode uint) (dto.LinkResponse, error) {
-	return s.service.Links(ctx, idAnime, episode)
+// Retorna información de múltiples servidores de video con URLs, códigos de embed e idioma.
+// Si se indican idiomas (ej: dto.LanguageLAT), solo retorna los enlaces de esos idiomas;
+// si el episodio no tiene ninguno, retorna un error que cumple errors.Is(err, ErrNotFound).
+func (s *AnimeFlv) Links(ctx context.Context, idAnime string, episode uint, languages ...dto.Language) (dto.LinkResponse, error) {
+	return s.service.Links(ctx, idAnime, episode, languages...)
+}
+
+// LinksRange obtiene los enlaces de los episodios entre from y to (inclusive) de un anime.
+// Los números de episodio válidos se obtienen de AnimeInfo; los episodios se consultan de forma
+// concurrente bajo el rate limiter, se cachean individualmente y se entregan a medida que
+// están disponibles (sin orden garantizado). Un episodio fallido se entrega con su error
+// sin detener la iteración. Si ctx se cancela, la iteración termina entregando el error del contexto.
+func (s *AnimeFlv) LinksRange(ctx context.Context, idAnime string, from, to uint) iter.Seq2[dto.LinkResponse, error] {
+	return s.service.LinksRange(ctx, idAnime, from, to)
+}
+
+// AllLinks obtiene los enlaces de todos los episodios disponibles de un anime.
+// Se comporta igual que LinksRange sin límites de episodio.
+func (s *AnimeFlv) AllLinks(ctx context.Context, idAnime string) iter.Seq2[dto.LinkResponse, error] {
+	return s.service.AllLinks(ctx, idAnime)
 }
 
 // RecentAnime obtiene la lista de animes recientemente agregados al sitio.
@@ -58,3 +209,45 @@ func (s *AnimeFlv) RecentAnime(ctx context.Context) ([]dto.AnimeStruct, error) {
 func (s *AnimeFlv) RecentEpisode(ctx context.Context) ([]dto.EpisodeListResponse, error) {
 	return s.service.RecentEpisode(ctx)
 }
+
+// OnAir obtiene la lista de animes actualmente en emisión, tomada de la barra lateral
+// de la página principal. Cada entrada incluye el ID, el título y el tipo del anime.
+// Se sirve desde la instantánea de Home y su entrada de caché "home": ya no se consulta ni
+// se cachea por separado, y si la barra lateral falta se retorna un *ParseError.
+func (s *AnimeFlv) OnAir(ctx context.Context) ([]dto.AnimeRef, error) {
+	return s.service.OnAir(ctx)
+}
+
+// Home obtiene en una sola petición los animes recientes, los episodios recientes y los
+// animes en emisión de la página principal. RecentAnime, RecentEpisode y OnAir se sirven
+// desde esta misma instantánea, por lo que combinarlos no genera peticiones adicionales.
+func (s *AnimeFlv) Home(ctx context.Context) (dto.HomeSnapshot, error) {
+	return s.service.Home(ctx)
+}
+
+// Schedule obtiene el calendario de emisión de la semana que comienza en weekStart
+// (cero equivale a hoy), con los próximos episodios de los animes en emisión agrupados por día.
+// La información de cada serie se guarda en un índice en memoria de esta instancia (no en el
+// caché configurado, aunque las consultas de AnimeInfo que lo alimentan sí pasan por él) y solo
+// se vuelve a consultar cuando su próximo episodio ya se emitió, cuando tiene más de 6 horas de
+// antigüedad o cuando su última consulta falló. Solo se retorna un error si se cancela el
+// contexto o si fallan todas las consultas y el índice no tiene información de ninguna serie
+// (por ejemplo ErrCircuitOpen en la primera llamada); los fallos aislados solo se registran.
+func (s *AnimeFlv) Schedule(ctx context.Context, weekStart time.Time) (dto.Schedule, error) {
+	return s.service.Schedule(ctx, weekStart)
+}
+
+// Franchise resuelve la franquicia de un anime: recorre sus animes relacionados (secuelas,
+// precuelas, historias paralelas, etc.) con detección de ciclos y un límite de profundidad,
+// y retorna los animes encontrados en un orden de visualización sugerido.
+func (s *AnimeFlv) Franchise(ctx context.Context, idAnime string) (dto.Franchise, error) {
+	return s.service.Franchise(ctx, idAnime)
+}
+
+// Crawl recorre el catálogo completo: todas las páginas de /browse y luego AnimeInfo de
+// cada anime descubierto, entregando cada resultado a opts.OnAnime. El progreso se guarda
+// en store tras cada paso y un rastreo interrumpido se reanuda donde se quedó. Todas las
+// peticiones respetan el rate limiter del cliente.
+func (s *AnimeFlv) Crawl(ctx context.Context, store ports.CheckpointStore, opts dto.CrawlOptions) error {
+	return s.service.Crawl(ctx, store, opts)
+}
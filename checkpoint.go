@@ -0,0 +1,22 @@
+// Package anime - checkpoint.go
+// Este archivo expone los almacenes de checkpoints que acepta Crawl para persistir
+// el progreso del rastreo del catálogo: un archivo JSON local o una clave de Valkey.
+package anime
+
+import (
+	"github.com/dst3v3n/api-anime/internal/adapters/checkpoint"
+	"github.com/dst3v3n/api-anime/internal/ports"
+	"github.com/valkey-io/valkey-go"
+)
+
+// NewFileCheckpointStore crea un almacén que guarda el checkpoint del rastreo en path.
+// Cada guardado es atómico, por lo que una caída nunca deja el archivo corrupto.
+func NewFileCheckpointStore(path string) ports.CheckpointStore {
+	return checkpoint.NewFile(path)
+}
+
+// NewValkeyCheckpointStore crea un almacén que guarda el checkpoint del rastreo bajo key
+// en Valkey, sin expiración. El cliente no se cierra al terminar el rastreo.
+func NewValkeyCheckpointStore(client valkey.Client, key string) ports.CheckpointStore {
+	return checkpoint.NewValkey(client, key)
+}
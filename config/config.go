@@ -2,6 +2,16 @@ package config
 
 import "github.com/dst3v3n/api-anime/internal/config"
 
+// RateLimit define el límite de peticiones al sitio, global o de una clase de operación
+// (ritmo sostenido en peticiones por segundo y ráfaga), usado por Config.WithGlobalRateLimit
+// y Config.WithRateLimits.
+// Es un alias del tipo equivalente en el package internal/config.
+type RateLimit = config.RateLimit
+
+// DefaultRateLimit es el límite por defecto, global y de cada clase de operación: 3 peticiones
+// por segundo con ráfagas de hasta 5.
+var DefaultRateLimit = config.DefaultRateLimit
+
 // NewConfigWithDefaults crea una nueva instancia de Config con valores por defecto.
 // Este es un wrapper que delega a la función equivalente en el package internal/config.
 // Retorna una Config preconfigurada sin necesidad de variables de entorno,
@@ -0,0 +1,63 @@
+// Package anime - errors.go
+// Este archivo re-exporta los errores tipados que la librería puede retornar, para que los
+// consumidores puedan distinguirlos con errors.Is y errors.As sin importar paquetes internos.
+//
+//	info, err := service.AnimeInfo(ctx, "id-inexistente")
+//	switch {
+//	case errors.Is(err, anime.ErrNotFound):
+//	    // el anime no existe
+//	case errors.Is(err, anime.ErrRateLimited):
+//	    // reintentar más tarde
+//	}
+//
+//	var parseErr *anime.ParseError
+//	if errors.As(err, &parseErr) {
+//	    // el sitio cambió su estructura en parseErr.Page / parseErr.Field
+//	}
+package anime
+
+import "github.com/dst3v3n/api-anime/internal/domain/errs"
+
+var (
+	// ErrNotFound se retorna cuando el recurso solicitado no existe en el sitio (HTTP 404).
+	ErrNotFound = errs.ErrNotFound
+
+	// ErrRateLimited se retorna cuando el sitio rechaza la petición por exceso de solicitudes (HTTP 429).
+	ErrRateLimited = errs.ErrRateLimited
+
+	// ErrCacheMiss se retorna cuando una clave no existe en el caché.
+	ErrCacheMiss = errs.ErrCacheMiss
+
+	// ErrInvalidInput se retorna cuando un parámetro no es válido (por ejemplo, un ID vacío).
+	ErrInvalidInput = errs.ErrInvalidInput
+
+	// ErrClosed se retorna cuando se invoca una operación después de Close.
+	ErrClosed = errs.ErrClosed
+
+	// ErrCircuitOpen se retorna cuando el circuit breaker del scraper está abierto
+	// y no hay una copia en caché que servir en su lugar.
+	ErrCircuitOpen = errs.ErrCircuitOpen
+
+	// ErrChallenged se retorna cuando el sitio responde con una verificación anti-bot
+	// (Cloudflare, DDoS-Guard) en lugar del contenido; ver ChallengeError para los detalles.
+	ErrChallenged = errs.ErrChallenged
+)
+
+// UpstreamStatusError se retorna cuando el sitio responde con un código de estado HTTP inesperado.
+// Satisface errors.Is con ErrNotFound (404) y ErrRateLimited (429).
+type UpstreamStatusError = errs.UpstreamStatusError
+
+// ChallengeError se retorna cuando el sitio sirve una página de verificación anti-bot.
+// Satisface errors.Is con ErrChallenged e incluye el proveedor, el indicio detectado y el CF-Ray.
+type ChallengeError = errs.ChallengeError
+
+// ParseError se retorna cuando el HTML recibido no contiene la información esperada.
+type ParseError = errs.ParseError
+
+// Páginas del sitio reportadas en ParseError.Page.
+const (
+	PageBrowse    = errs.PageBrowse
+	PageAnimeInfo = errs.PageAnimeInfo
+	PageEpisode   = errs.PageEpisode
+	PageHome      = errs.PageHome
+)
@@ -7,10 +7,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/dst3v3n/api-anime/internal/config"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
 	"github.com/dst3v3n/api-anime/internal/ports"
 	"github.com/valkey-io/valkey-go"
 )
@@ -24,19 +27,42 @@ type Valkey struct {
 
 // NewValkeyCache crea una nueva instancia del adaptador de caché Valkey.
 // Toma un cliente Valkey ya inicializado y retorna una instancia que implementa CachePort.
+// Lee la configuración del singleton global; usa NewValkeyCacheWithConfig para inyectarla.
 func NewValkeyCache(client valkey.Client) ports.CachePort {
 	enviroment, err := config.GetConfig()
 	if err != nil {
 		return nil
 	}
+	return NewValkeyCacheWithConfig(client, enviroment)
+}
+
+// NewValkeyCacheWithConfig crea el adaptador de caché Valkey con una configuración explícita,
+// sin depender del singleton global de configuración.
+func NewValkeyCacheWithConfig(client valkey.Client, cfg *config.Config) ports.CachePort {
 	return &Valkey{
 		client: client,
-		config: enviroment,
+		config: cfg,
+	}
+}
+
+// NewValkeyClient abre una conexión a Valkey a partir de la configuración de caché.
+// Usa host, puerto, credenciales y base de datos definidos en cfg.
+// Retorna error si no es posible establecer la conexión inicial.
+func NewValkeyClient(cfg *config.Config) (valkey.Client, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("la configuración de caché no puede ser nil")
 	}
+
+	return valkey.NewClient(valkey.ClientOption{
+		InitAddress: []string{net.JoinHostPort(cfg.CacheHost, strconv.Itoa(cfg.CachePort))},
+		Username:    cfg.CacheUsername,
+		Password:    cfg.CachePassword,
+		SelectDB:    cfg.CacheDB,
+	})
 }
 
 // Get recupera un valor del caché por su clave y lo deserializa en el destino proporcionado.
-// Utiliza un tiempo de caché de 1 minuto. Si la clave no existe, retorna nil sin error.
+// Utiliza un tiempo de caché de 1 minuto. Si la clave no existe, retorna errs.ErrCacheMiss.
 // Si el valor existe pero no puede ser deserializado, retorna un error.
 func (v *Valkey) Get(ctx context.Context, key string, dest interface{}) error {
 	ttl := v.config.CacheTTL
@@ -44,7 +70,7 @@ func (v *Valkey) Get(ctx context.Context, key string, dest interface{}) error {
 
 	if error != nil {
 		if valkey.IsValkeyNil(error) {
-			return fmt.Errorf("key not found in cache")
+			return errs.ErrCacheMiss
 		}
 		return error
 	}
@@ -56,6 +82,12 @@ func (v *Valkey) Get(ctx context.Context, key string, dest interface{}) error {
 // Serializa el valor a JSON y lo guarda con una TTL de 15 minutos.
 // Retorna error si falla la serialización o la operación de almacenamiento.
 func (v *Valkey) Set(ctx context.Context, key string, value interface{}) error {
+	return v.SetWithTTL(ctx, key, value, time.Minute)
+}
+
+// SetWithTTL almacena un valor en el caché con la TTL indicada.
+// Implementa ports.CacheTTLPort; aplica las mismas validaciones de serialización que Set.
+func (v *Valkey) SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
 	r, _ := regexp.Compile(`^\s*[\[{]`)
 
 	if value == nil {
@@ -71,7 +103,7 @@ func (v *Valkey) Set(ctx context.Context, key string, value interface{}) error {
 		return fmt.Errorf("Error: valor no es serializable a JSON")
 	}
 
-	cmd := v.client.B().Set().Key(key).Value(string(data)).Ex(time.Minute).Build()
+	cmd := v.client.B().Set().Key(key).Value(string(data)).Ex(ttl).Build()
 	return v.client.Do(ctx, cmd).Error()
 }
 
@@ -0,0 +1,91 @@
+// Package checkpoint implementa adaptadores del puerto CheckpointStore.
+// Este archivo (file.go) guarda el checkpoint del rastreador como JSON en un archivo
+// local. Cada guardado escribe un archivo temporal y lo renombra, de modo que una
+// caída a mitad de la escritura nunca deja un checkpoint corrupto.
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/ports"
+)
+
+// File es la implementación de CheckpointStore sobre un archivo JSON.
+type File struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFile crea un CheckpointStore que guarda el checkpoint en path.
+// El directorio de path debe existir.
+func NewFile(path string) ports.CheckpointStore {
+	return &File{path: path}
+}
+
+// Load lee el checkpoint del archivo. Si el archivo no existe, retorna found en false.
+func (f *File) Load(_ context.Context) (dto.CrawlCheckpoint, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return dto.CrawlCheckpoint{}, false, nil
+	}
+	if err != nil {
+		return dto.CrawlCheckpoint{}, false, fmt.Errorf("error al leer el checkpoint %s: %w", f.path, err)
+	}
+
+	var checkpoint dto.CrawlCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return dto.CrawlCheckpoint{}, false, fmt.Errorf("checkpoint %s inválido: %w", f.path, err)
+	}
+	return checkpoint, true, nil
+}
+
+// Save escribe el checkpoint en un archivo temporal y lo renombra sobre path.
+func (f *File) Save(_ context.Context, checkpoint dto.CrawlCheckpoint) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("error al serializar el checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error al crear el checkpoint temporal: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error al escribir el checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error al escribir el checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("error al guardar el checkpoint %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// Clear elimina el archivo de checkpoint si existe.
+func (f *File) Clear(_ context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.Remove(f.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error al eliminar el checkpoint %s: %w", f.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Package checkpoint - valkey.go
+// Este archivo guarda el checkpoint del rastreador como JSON bajo una clave de Valkey,
+// sin expiración, para que varias instancias puedan reanudar el mismo rastreo.
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/ports"
+	"github.com/valkey-io/valkey-go"
+)
+
+// Valkey es la implementación de CheckpointStore sobre una clave de Valkey.
+type Valkey struct {
+	client valkey.Client
+	key    string
+}
+
+// NewValkey crea un CheckpointStore que guarda el checkpoint bajo key.
+// El cliente no se cierra al terminar el rastreo; su ciclo de vida pertenece a quien lo creó.
+func NewValkey(client valkey.Client, key string) ports.CheckpointStore {
+	return &Valkey{client: client, key: key}
+}
+
+// Load lee el checkpoint de la clave. Si la clave no existe, retorna found en false.
+func (v *Valkey) Load(ctx context.Context) (dto.CrawlCheckpoint, bool, error) {
+	data, err := v.client.Do(ctx, v.client.B().Get().Key(v.key).Build()).ToString()
+	if valkey.IsValkeyNil(err) {
+		return dto.CrawlCheckpoint{}, false, nil
+	}
+	if err != nil {
+		return dto.CrawlCheckpoint{}, false, fmt.Errorf("error al leer el checkpoint %s: %w", v.key, err)
+	}
+
+	var checkpoint dto.CrawlCheckpoint
+	if err := json.Unmarshal([]byte(data), &checkpoint); err != nil {
+		return dto.CrawlCheckpoint{}, false, fmt.Errorf("checkpoint %s inválido: %w", v.key, err)
+	}
+	return checkpoint, true, nil
+}
+
+// Save guarda el checkpoint bajo la clave, sin expiración.
+func (v *Valkey) Save(ctx context.Context, checkpoint dto.CrawlCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("error al serializar el checkpoint: %w", err)
+	}
+	return v.client.Do(ctx, v.client.B().Set().Key(v.key).Value(string(data)).Build()).Error()
+}
+
+// Clear elimina la clave del checkpoint.
+func (v *Valkey) Clear(ctx context.Context) error {
+	return v.client.Do(ctx, v.client.B().Del().Key(v.key).Build()).Error()
+}
@@ -0,0 +1,102 @@
+// Package animeflv - breaker.go
+// Este archivo implementa el circuit breaker que protege al sitio cuando está caído.
+// Tras un número configurable de peticiones fallidas consecutivas el circuito se abre
+// y las peticiones se rechazan de inmediato con errs.ErrCircuitOpen, sin esperar el
+// timeout HTTP. Terminado el enfriamiento, el circuito pasa a semiabierto y deja pasar
+// una única petición de prueba: si tiene éxito el circuito se cierra y si falla se reabre.
+package animeflv
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+)
+
+// circuitBreaker lleva el estado del circuito de las peticiones al sitio.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	state     dto.CircuitState
+	failures  int       // Fallos consecutivos en estado cerrado
+	openedAt  time.Time // Momento en que se abrió el circuito
+	probing   bool      // Hay una petición de prueba en curso (estado semiabierto)
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+}
+
+// newCircuitBreaker crea un circuito cerrado. Un threshold de 0 desactiva el circuito.
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		state:     dto.CircuitClosed,
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// State retorna el estado actual del circuito. Un circuito abierto cuyo enfriamiento
+// ya terminó se reporta como semiabierto.
+func (b *circuitBreaker) State() dto.CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == dto.CircuitOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		return dto.CircuitHalfOpen
+	}
+	return b.state
+}
+
+// allow decide si una petición puede contactar al sitio. Con el circuito abierto retorna
+// errs.ErrCircuitOpen; en semiabierto solo deja pasar una petición de prueba a la vez.
+func (b *circuitBreaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case dto.CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return errs.ErrCircuitOpen
+		}
+		b.state = dto.CircuitHalfOpen
+		fallthrough
+	case dto.CircuitHalfOpen:
+		if b.probing {
+			return errs.ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record registra el resultado de una petición permitida por allow. Los fallos transitorios
+// del sitio (errores de conexión, timeouts, 5xx y 429) y las verificaciones anti-bot cuentan
+// como fallo; si ctx se canceló o venció, el resultado no cuenta ni como fallo ni como éxito.
+func (b *circuitBreaker) record(ctx context.Context, err error) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case ctx.Err() != nil:
+		b.probing = false
+	case err != nil && (retryable(err) || errors.Is(err, errs.ErrChallenged)):
+		b.failures++
+		if b.state == dto.CircuitHalfOpen || b.failures >= b.threshold {
+			b.state = dto.CircuitOpen
+			b.openedAt = b.now()
+			b.probing = false
+		}
+	default:
+		b.state = dto.CircuitClosed
+		b.failures = 0
+		b.probing = false
+	}
+}
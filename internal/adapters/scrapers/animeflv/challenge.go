@@ -0,0 +1,77 @@
+// Package animeflv - challenge.go
+// Este archivo detecta las páginas de verificación anti-bot (desafío JavaScript de Cloudflare,
+// páginas de bloqueo, DDoS-Guard) que el sitio puede servir con un 200, 403 o 503 en lugar
+// del contenido. Detectarlas antes del parsing permite retornar errs.ErrChallenged en vez de
+// un ParseError engañoso, y evita que esas respuestas lleguen al caché.
+package animeflv
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+)
+
+// challengePeekSize es el número de bytes del cuerpo inspeccionados en busca de indicios.
+// Las páginas de verificación declaran sus indicios en el <head>.
+const challengePeekSize = 32 * 1024
+
+// challengeMarker es un indicio en el cuerpo de una página de verificación.
+type challengeMarker struct {
+	text     string // Texto buscado, en minúsculas
+	provider string // Proveedor al que pertenece el indicio
+}
+
+// challengeMarkers son los indicios de páginas de verificación conocidas. Solo incluye textos
+// exclusivos de las páginas de verificación: los scripts que Cloudflare inyecta en páginas
+// normales (como cloudflareinsights o challenge-platform/scripts) no se consideran indicios.
+var challengeMarkers = []challengeMarker{
+	{text: "_cf_chl_opt", provider: "cloudflare"},
+	{text: "cf-browser-verification", provider: "cloudflare"},
+	{text: "<title>just a moment...</title>", provider: "cloudflare"},
+	{text: "checking your browser before accessing", provider: "cloudflare"},
+	{text: "attention required! | cloudflare", provider: "cloudflare"},
+	{text: "enable javascript and cookies to continue", provider: "cloudflare"},
+	{text: "<title>ddos-guard</title>", provider: "ddos-guard"},
+}
+
+// challengeStatus reporta si un código de estado puede corresponder a una página de verificación.
+func challengeStatus(code int) bool {
+	return code == http.StatusOK || code == http.StatusForbidden || code == http.StatusServiceUnavailable
+}
+
+// detectChallenge inspecciona la respuesta en busca de una página de verificación anti-bot.
+// Lee como máximo challengePeekSize bytes y repone el cuerpo para que el parser lo lea completo.
+// Retorna un *errs.ChallengeError si la respuesta es una verificación, o nil en otro caso.
+func detectChallenge(resp *http.Response, target string) error {
+	challenge := &errs.ChallengeError{
+		URL:   target,
+		Code:  resp.StatusCode,
+		RayID: resp.Header.Get("CF-Ray"),
+	}
+
+	if strings.EqualFold(resp.Header.Get("CF-Mitigated"), "challenge") {
+		challenge.Provider, challenge.Marker = "cloudflare", "cabecera cf-mitigated"
+		return challenge
+	}
+
+	head, err := io.ReadAll(io.LimitReader(resp.Body, challengePeekSize))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), resp.Body), resp.Body}
+	if err != nil {
+		return nil
+	}
+
+	lower := bytes.ToLower(head)
+	for _, marker := range challengeMarkers {
+		if bytes.Contains(lower, []byte(marker.text)) {
+			challenge.Provider, challenge.Marker = marker.provider, marker.text
+			return challenge
+		}
+	}
+	return nil
+}
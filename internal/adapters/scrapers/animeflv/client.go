@@ -1,26 +1,41 @@
 // Package animeflv implementa un cliente scraper para el sitio web AnimeFlv.
 // Este archivo (client.go) contiene la estructura principal del cliente y la implementación
 // de todos los métodos definidos en el port ScraperPort. Se encarga de realizar las
-// peticiones HTTP a AnimeFlv y delegar el parsing del HTML al componente Parser.
+// peticiones HTTP a AnimeFlv, con failover entre la URL base y los mirrors, reintentos ante
+// fallos transitorios y un límite de peticiones por clase de operación, y delegar el parsing
+// del HTML al componente Parser.
 package animeflv
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
+	"github.com/dst3v3n/api-anime/internal/config"
 	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
 	"github.com/dst3v3n/api-anime/internal/ports"
-	"golang.org/x/time/rate"
+)
+
+// Rutas del sitio, relativas a la URL base o a un mirror.
+const (
+	homePath       = "/"
+	searchPath     = "/browse"
+	animeInfoPath  = "/anime/"
+	verEpisodePath = "/ver/"
 )
 
 // Client es la estructura principal del scraper de AnimeFlv.
-// Contiene la configuración de URLs del sitio y una instancia del parser HTML.
+// Contiene los hosts del sitio con su estado de salud y una instancia del parser HTML.
 type Client struct {
-	config  Config
+	mirrors *mirrorPool
+	retry   retryPolicy
+	breaker *circuitBreaker
 	parser  *Parser
-	limiter *rate.Limiter
+	limits  rateLimiters
 	client  *http.Client
 }
 
@@ -28,32 +43,141 @@ type Client struct {
 // Inicializa la configuración con las URLs del sitio y crea el parser HTML.
 // Retorna una interfaz ScraperPort para permitir la inyección de dependencias.
 func NewClient() ports.ScraperPort {
+	return NewClientWithHTTP(NewDefaultHTTPClient())
+}
+
+// NewDefaultHTTPClient crea el cliente HTTP usado por defecto por el scraper,
+// con un timeout de 30 segundos por petición.
+func NewDefaultHTTPClient() *http.Client {
+	return &http.Client{
+		Timeout: 30 * time.Second,
+	}
+}
+
+// NewClientWithHTTP crea el cliente scraper usando el *http.Client proporcionado.
+// Permite que el consumidor controle timeouts, transporte y proxies.
+// Si httpClient es nil se usa un cliente con timeout de 30 segundos.
+func NewClientWithHTTP(httpClient *http.Client) ports.ScraperPort {
+	return NewClientWithConfig(httpClient, config.NewConfigWithDefaults())
+}
+
+// NewClientWithConfig crea el cliente scraper usando el *http.Client proporcionado y la
+// URL base, los mirrors, el enfriamiento de hosts caídos, la política de reintentos, el
+// circuit breaker y los límites de peticiones definidos en cfg.
+// Si httpClient es nil se usa un cliente con timeout de 30 segundos.
+func NewClientWithConfig(httpClient *http.Client, cfg *config.Config) ports.ScraperPort {
+	if httpClient == nil {
+		httpClient = NewDefaultHTTPClient()
+	}
+	if cfg == nil {
+		cfg = config.NewConfigWithDefaults()
+	}
+
 	return &Client{
-		config: Config{
-			BaseURL:       "https://www3.animeflv.net",
-			SearchURL:     "https://www3.animeflv.net/browse",
-			AnimeInfoURL:  "https://www3.animeflv.net/anime",
-			VerEpisodeURL: "https://www3.animeflv.net/ver",
-		},
+		mirrors: newMirrorPool(cfg.Hosts(), cfg.MirrorCooldown),
+		retry:   newRetryPolicy(cfg),
+		breaker: newCircuitBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
 		parser:  NewParser(),
-		limiter: rate.NewLimiter(rate.Limit(3), 5),
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		limits:  newRateLimiters(cfg),
+		client:  httpClient,
 	}
 }
 
+// CircuitState retorna el estado actual del circuit breaker del cliente.
+// Implementa ports.CircuitStatePort.
+func (c *Client) CircuitState() dto.CircuitState {
+	return c.breaker.State()
+}
+
+// RateLimits retorna el estado del límite de peticiones de cada clase de operación,
+// incluido su ritmo efectivo tras los 429 recientes. Implementa ports.RateLimitPort.
+func (c *Client) RateLimits() []dto.RateLimitState {
+	return c.limits.states()
+}
+
 // doRequest es el método centralizado para realizar todas las peticiones HTTP.
-// Aplica rate limiting automáticamente antes de cada petición y maneja timeouts.
-// Este método garantiza que todas las peticiones al sitio respeten los límites establecidos.
-func (c *Client) doRequest(ctx context.Context, url string) (*http.Response, error) {
+// Si el circuit breaker está abierto retorna errs.ErrCircuitOpen sin contactar al sitio;
+// en otro caso ejecuta la petición con reintentos y registra su resultado en el circuito.
+func (c *Client) doRequest(ctx context.Context, path string) (*http.Response, error) {
+	if err := c.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequestWithRetry(ctx, c.limits.class(path), path)
+	c.breaker.record(ctx, err)
+	return resp, err
+}
+
+// doRequestWithRetry realiza la petición a path con reintentos.
+// Cada intento recorre los hosts disponibles (ver requestHosts). Si el intento termina en un
+// fallo transitorio (error de conexión, 5xx o 429) se repite hasta el máximo de intentos,
+// esperando según la política de reintentos o la cabecera Retry-After. Los 404 y demás 4xx
+// se retornan de inmediato, y no se espera más allá del deadline del contexto.
+func (c *Client) doRequestWithRetry(ctx context.Context, limiter *adaptiveLimiter, path string) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.requestHosts(ctx, limiter, path)
+		if err == nil || ctx.Err() != nil || !retryable(err) || attempt >= c.retry.maxAttempts {
+			return resp, err
+		}
+
+		wait, ok := c.retry.delay(attempt, err)
+		if !ok || !waitRetry(ctx, wait) {
+			return nil, err
+		}
+	}
+}
+
+// requestHosts resuelve path contra los hosts sanos en orden de preferencia: si un host falla
+// por error de conexión o responde 5xx, lo marca como caído y prueba el siguiente. Cualquier otra
+// respuesta (incluidos los 4xx) se retorna sin probar otros hosts.
+func (c *Client) requestHosts(ctx context.Context, limiter *adaptiveLimiter, path string) (*http.Response, error) {
+	var lastErr error
+	for _, host := range c.mirrors.candidates() {
+		resp, err := c.fetch(ctx, limiter, host+path)
+		if err == nil {
+			c.mirrors.markUp(host)
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		if !shouldFailover(err) {
+			c.mirrors.markUp(host)
+			return nil, err
+		}
+
+		c.mirrors.markDown(host)
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// shouldFailover reporta si err indica que el host no está disponible:
+// un error de conexión, una respuesta 5xx o una verificación anti-bot.
+func shouldFailover(err error) bool {
+	if errors.Is(err, errs.ErrChallenged) {
+		return true
+	}
+	var statusErr *errs.UpstreamStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code >= http.StatusInternalServerError
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// fetch realiza un único intento de petición HTTP contra una URL absoluta.
+// Aplica el límite de peticiones de la clase de operación y el global antes de la petición y maneja timeouts.
+// Este método garantiza que todas las peticiones al sitio respeten los límites establecidos,
+// y reduce el límite de la clase cuando el sitio responde 429.
+func (c *Client) fetch(ctx context.Context, limiter *adaptiveLimiter, target string) (*http.Response, error) {
 	// Espera hasta que el rate limiter permita la petición
-	if err := c.limiter.Wait(ctx); err != nil {
+	if err := c.limits.wait(ctx, limiter); err != nil {
 		return nil, fmt.Errorf("rate limiter cancelado: %w", err)
 	}
 
 	// Crea la petición HTTP con el contexto
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error creando petición HTTP: %w", err)
 	}
@@ -64,10 +188,26 @@ func (c *Client) doRequest(ctx context.Context, url string) (*http.Response, err
 		return nil, fmt.Errorf("error en la petición HTTP: %w", err)
 	}
 
+	// Una página de verificación anti-bot no es el contenido solicitado, aunque llegue con 200
+	if challengeStatus(resp.StatusCode) {
+		if err := detectChallenge(resp, target); err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		limiter.slowDown()
+	}
+
 	// Valida el código de estado
 	if resp.StatusCode != http.StatusOK {
 		resp.Body.Close()
-		return nil, fmt.Errorf("código de estado HTTP inesperado: %d", resp.StatusCode)
+		return nil, &errs.UpstreamStatusError{
+			Code:       resp.StatusCode,
+			URL:        target,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
 	}
 
 	return resp, nil
@@ -84,9 +224,7 @@ func (c *Client) SearchAnime(ctx context.Context, anime string, page string) (dt
 		"page": page,
 		"q":    anime,
 	}
-	searchURL := buildURL(c.config.SearchURL, params)
-
-	resp, err := c.doRequest(ctx, searchURL)
+	resp, err := c.doRequest(ctx, buildURL(searchPath, params))
 	if err != nil {
 		return dto.AnimeResponse{}, err
 	}
@@ -100,7 +238,7 @@ func (c *Client) SearchAnime(ctx context.Context, anime string, page string) (dt
 // Realiza una petición HTTP GET a la página de búsqueda sin parámetros de consulta
 // y retorna todos los animes con información de paginación.
 func (c *Client) Search(ctx context.Context) (dto.AnimeResponse, error) {
-	resp, err := c.doRequest(ctx, c.config.SearchURL)
+	resp, err := c.doRequest(ctx, searchPath)
 	if err != nil {
 		return dto.AnimeResponse{}, err
 	}
@@ -110,12 +248,39 @@ func (c *Client) Search(ctx context.Context) (dto.AnimeResponse, error) {
 	return c.parser.ParseAnimeWithPagination(resp.Body)
 }
 
+// Browse obtiene una página del catálogo aplicando los filtros de género, año, tipo,
+// estado y orden soportados por el endpoint /browse del sitio.
+func (c *Client) Browse(ctx context.Context, filter dto.BrowseFilter, page string) (dto.AnimeResponse, error) {
+	if page == "" {
+		page = "1"
+	}
+	resp, err := c.doRequest(ctx, buildURLValues(searchPath, browseParams(filter, page)))
+	if err != nil {
+		return dto.AnimeResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	return c.parser.ParseAnimeWithPagination(resp.Body)
+}
+
+// Genres obtiene el catálogo de géneros desde el formulario de filtros de /browse.
+func (c *Client) Genres(ctx context.Context) ([]dto.Genre, error) {
+	resp, err := c.doRequest(ctx, searchPath)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	return c.parser.ParseGenres(resp.Body)
+}
+
 // AnimeInfo obtiene información detallada de un anime específico por su ID.
 // Incluye sinopsis completa, géneros, estado de emisión, episodios disponibles,
 // animes relacionados y fecha del próximo episodio si aplica.
 func (c *Client) AnimeInfo(ctx context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
-	searchURL := c.config.AnimeInfoURL + "/" + idAnime
-	resp, err := c.doRequest(ctx, searchURL)
+	resp, err := c.doRequest(ctx, animeInfoPath+idAnime)
 	if err != nil {
 		return dto.AnimeInfoResponse{}, err
 	}
@@ -128,8 +293,7 @@ func (c *Client) AnimeInfo(ctx context.Context, idAnime string) (dto.AnimeInfoRe
 // Links obtiene los enlaces de reproducción/descarga de un episodio específico.
 // Retorna información de múltiples servidores de video con sus URLs y códigos de embed.
 func (c *Client) Links(ctx context.Context, idAnime string, episode uint) (dto.LinkResponse, error) {
-	searchURL := fmt.Sprintf("%s/%s-%d", c.config.VerEpisodeURL, idAnime, episode)
-	resp, err := c.doRequest(ctx, searchURL)
+	resp, err := c.doRequest(ctx, fmt.Sprintf("%s%s-%d", verEpisodePath, idAnime, episode))
 	if err != nil {
 		return dto.LinkResponse{}, err
 	}
@@ -139,28 +303,15 @@ func (c *Client) Links(ctx context.Context, idAnime string, episode uint) (dto.L
 	return c.parser.ParseLinks(resp.Body, idAnime, episode)
 }
 
-// RecentAnime obtiene la lista de animes recientemente agregados al sitio.
-// Scrapeando la página principal de AnimeFlv para extraer los últimos contenidos publicados.
-func (c *Client) RecentAnime(ctx context.Context) ([]dto.AnimeStruct, error) {
-	resp, err := c.doRequest(ctx, c.config.BaseURL)
-	if err != nil {
-		return nil, err
-	}
-
-	defer resp.Body.Close()
-
-	return c.parser.ParseAnime(resp.Body)
-}
-
-// RecentEpisode obtiene la lista de episodios recientemente publicados.
-// Incluye información del anime, número de episodio, capítulo e imagen de portada.
-func (c *Client) RecentEpisode(ctx context.Context) ([]dto.EpisodeListResponse, error) {
-	resp, err := c.doRequest(ctx, c.config.BaseURL)
+// Home obtiene en una sola petición todas las secciones de la página principal:
+// animes recientes, episodios recientes y animes en emisión.
+func (c *Client) Home(ctx context.Context) (dto.HomeSnapshot, error) {
+	resp, err := c.doRequest(ctx, homePath)
 	if err != nil {
-		return nil, err
+		return dto.HomeSnapshot{}, err
 	}
 
 	defer resp.Body.Close()
 
-	return c.parser.ParseRecentEpisode(resp.Body)
+	return c.parser.ParseHome(resp.Body)
 }
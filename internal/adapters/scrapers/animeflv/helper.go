@@ -3,30 +3,34 @@
 // obtenidos del scraping de AnimeFlv. Incluye funciones para:
 // - Parsear valores numéricos (episodios, puntuaciones)
 // - Extraer IDs y números de episodio desde URLs
-// - Construir URLs con parámetros de consulta
+// - Construir URLs con parámetros de consulta (incluidos los filtros del catálogo)
 // - Manipular strings para limpiar y formatear datos
+// - Clasificar los tipos de relación entre animes
 package animeflv
 
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
 )
 
 // episodeInfo extrae información de episodios desde el contenido de un script JavaScript.
 // Combina los resultados de scriptEpisodeList y scriptInfo para obtener
-// tanto la lista de episodios disponibles como la fecha del próximo episodio.
-func episodeInfo(scriptContent string) ([]int, string, error) {
+// la lista de episodios disponibles, el ID numérico del anime y la fecha del próximo episodio.
+func episodeInfo(scriptContent string) ([]EpisodeRef, int, string, error) {
 	episodios, err := scriptEpisodeList(scriptContent)
 	if err != nil {
-		return nil, "", fmt.Errorf("error al obtener lista de episodios: %w", err)
+		return nil, 0, "", fmt.Errorf("error al obtener lista de episodios: %w", err)
 	}
-	nextEpisode, err := scriptInfo(scriptContent)
+	animeID, nextEpisode, err := scriptInfo(scriptContent)
 	if err != nil {
-		return episodios, "", fmt.Errorf("error al obtener información del próximo episodio: %w", err)
+		return episodios, 0, "", fmt.Errorf("error al obtener información del próximo episodio: %w", err)
 	}
-	return episodios, nextEpisode, nil
+	return episodios, animeID, nextEpisode, nil
 }
 
 // parseFloat convierte una cadena a float64 con validación.
@@ -67,6 +71,45 @@ func extractEpisodeNumber(href string) (int, error) {
 	return 0, fmt.Errorf("formato de href inválido para extracción de episodio: %s", href)
 }
 
+// extractBackgroundURL extrae la URL de una regla CSS background-image.
+// Ejemplo: "background-image:url(/uploads/animes/banners/3.jpg)" -> "/uploads/animes/banners/3.jpg"
+// Retorna una cadena vacía si el estilo no contiene ninguna URL.
+func extractBackgroundURL(style string) string {
+	matches := backgroundURLRegex.FindStringSubmatch(style)
+	if len(matches) < 2 {
+		return ""
+	}
+	return strings.Trim(matches[1], `"' `)
+}
+
+// backgroundURLRegex captura el argumento de url(...) en una regla CSS.
+var backgroundURLRegex = regexp.MustCompile(`url\(([^)]*)\)`)
+
+// relationTypes asocia las etiquetas de relación del sitio, normalizadas, con su tipo.
+var relationTypes = map[string]dto.RelationType{
+	"secuela":             dto.RelationSequel,
+	"precuela":            dto.RelationPrequel,
+	"historia paralela":   dto.RelationSideStory,
+	"historia principal":  dto.RelationParentStory,
+	"version alternativa": dto.RelationAlternative,
+	"spin off":            dto.RelationSpinOff,
+	"spinoff":             dto.RelationSpinOff,
+}
+
+// relationNormalizer pasa a minúsculas sin tildes y sin guiones una etiqueta de relación.
+var relationNormalizer = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "-", " ")
+
+// classifyRelation convierte la etiqueta de relación del sitio en un RelationType.
+// Ejemplo: "Versión Alternativa" -> dto.RelationAlternative
+// Las etiquetas desconocidas se clasifican como dto.RelationOther.
+func classifyRelation(category string) dto.RelationType {
+	key := relationNormalizer.Replace(strings.ToLower(strings.TrimSpace(category)))
+	if relationType, ok := relationTypes[key]; ok {
+		return relationType
+	}
+	return dto.RelationOther
+}
+
 // removeTrailingNumber elimina el número final de un ID si existe.
 // Ejemplo: "one-piece-tv-1150" -> "one-piece-tv"
 // Útil para normalizar IDs de episodios a IDs de anime.
@@ -96,6 +139,46 @@ func buildURL(baseURL string, params map[string]string) string {
 	return u.String()
 }
 
+// buildURLValues construye una URL completa con parámetros de consulta que pueden repetirse.
+// Ejemplo: buildURLValues("https://example.com/browse", {"genre[]": {"accion", "drama"}})
+// retorna "https://example.com/browse?genre%5B%5D=accion&genre%5B%5D=drama"
+func buildURLValues(baseURL string, params url.Values) string {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL
+	}
+	query := u.Query()
+	for key, values := range params {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// browseParams convierte un filtro del catálogo en los parámetros de consulta de /browse.
+func browseParams(filter dto.BrowseFilter, page string) url.Values {
+	params := url.Values{}
+	for _, genre := range filter.Genres {
+		params.Add("genre[]", genre)
+	}
+	for _, year := range filter.Years {
+		params.Add("year[]", strconv.Itoa(year))
+	}
+	for _, tipo := range filter.Types {
+		params.Add("type[]", string(tipo))
+	}
+	for _, status := range filter.Status {
+		params.Add("status[]", strconv.Itoa(int(status)))
+	}
+	if filter.Order != "" {
+		params.Set("order", string(filter.Order))
+	}
+	params.Set("page", page)
+	return params
+}
+
 // parseUint convierte una cadena a uint con validación.
 // Retorna error si la cadena no tiene un formato numérico válido o es un número negativo.
 func parseUint(value string) (uint, error) {
@@ -5,6 +5,9 @@
 // - Información detallada de anime (géneros, estado, episodios, animes relacionados)
 // - Enlaces de reproducción de episodios
 // - Listado de episodios recientes
+// - Animes en emisión de la barra lateral de la página de inicio
+// - Instantánea completa de la página de inicio en una sola lectura
+// - Catálogo de géneros del formulario de filtros
 // Define todos los selectores CSS necesarios y coordina el proceso de extracción y mapeo de datos.
 
 package animeflv
@@ -14,13 +17,16 @@ import (
 	"html"
 	"io"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
 )
 
 const (
+	selectorSearchGrid         = "ul.ListAnimes"
 	selectorSearchArticle      = "ul.ListAnimes > li > article"
 	selectorArticleLink        = "a"
 	selectorArticleTitle       = "h3.Title"
@@ -38,6 +44,21 @@ const (
 	selectorInfoImage       = "div.Image img"
 	selectorInfoGenres      = "nav.Nvgnrs a"
 	selectorInfoRelated     = "ul.ListAnmRel > li"
+	selectorInfoAltTitles   = "div.Ficha span.TxtAlt"
+	selectorInfoVotes       = "#votes_nmbr"
+	selectorInfoFollowers   = "div.Title:contains('Seguidores') > span"
+	selectorInfoBanner      = "div.Ficha div.Bg"
+
+	selectorOnAirItem = "div.Wdgt.Emision ul.ListSdbr > li > a"
+	selectorOnAirType = "span.Type"
+
+	selectorGenreOption = "select#genre_select option"
+
+	selectorEpisodePrev = "div.CapNv a.CapNvPv"
+	selectorEpisodeNext = "div.CapNv a.CapNvNx"
+
+	selectorDownloadRow  = "table.Dwnl tbody tr"
+	selectorDownloadCell = "td"
 
 	selectorEpisodeList        = "ul.ListEpisodios > li"
 	selectorEpisodeListTitle   = "strong.Title"
@@ -62,7 +83,7 @@ func NewParser() *Parser {
 // Utilizado tanto para resultados de búsqueda como para animes recientes.
 // Extrae: ID, título, sinopsis, tipo, puntuación e imagen de cada anime.
 func (p *Parser) ParseAnime(htmlElement io.Reader) ([]dto.AnimeStruct, error) {
-	result, err := p.ParseAnimeWithPagination(htmlElement)
+	result, err := p.parseAnimeList(htmlElement, errs.PageHome)
 	if err != nil {
 		return result.Animes, fmt.Errorf("error al parsear animes: %w", err)
 	}
@@ -70,11 +91,31 @@ func (p *Parser) ParseAnime(htmlElement io.Reader) ([]dto.AnimeStruct, error) {
 	return result.Animes, nil
 }
 
+// ParseAnimeWithPagination extrae la lista de animes de una página de búsqueda
+// junto con el total de páginas disponibles. Una cuadrícula vacía (una búsqueda sin
+// coincidencias) es un resultado vacío válido; si falta la cuadrícula retorna un *errs.ParseError.
 func (p *Parser) ParseAnimeWithPagination(htmlElement io.Reader) (dto.AnimeResponse, error) {
-	results := dto.AnimeResponse{}
+	return p.parseAnimeList(htmlElement, errs.PageBrowse)
+}
+
+// parseAnimeList extrae la cuadrícula de animes y la paginación del HTML.
+// page identifica la página de origen en el *errs.ParseError retornado si falta la cuadrícula.
+func (p *Parser) parseAnimeList(htmlElement io.Reader, page string) (dto.AnimeResponse, error) {
 	doc, err := goquery.NewDocumentFromReader(htmlElement)
 	if err != nil {
-		return results, fmt.Errorf("error al crear documento desde HTML: %w", err)
+		return dto.AnimeResponse{}, &errs.ParseError{Page: page, Field: "html", Err: err}
+	}
+
+	return p.animeListFromDocument(doc, page)
+}
+
+// animeListFromDocument extrae la cuadrícula de animes y la paginación de un documento ya parseado.
+// Si la cuadrícula no existe (cambio de estructura del sitio) retorna un *errs.ParseError; si existe
+// pero no contiene animes retorna un resultado vacío sin error.
+func (p *Parser) animeListFromDocument(doc *goquery.Document, page string) (dto.AnimeResponse, error) {
+	results := dto.AnimeResponse{}
+	if doc.Find(selectorSearchGrid).Length() == 0 {
+		return results, &errs.ParseError{Page: page, Field: "animes"}
 	}
 
 	doc.Find(selectorSearchArticle).Each(func(_ int, s *goquery.Selection) {
@@ -107,11 +148,87 @@ func (p *Parser) ParseAnimeWithPagination(htmlElement io.Reader) (dto.AnimeRespo
 		}
 	})
 
-	if len(results.Animes) == 0 {
-		return results, fmt.Errorf("no se encontraron animes en el HTML proporcionado")
+	return results, nil
+}
+
+// ParseHome extrae de una sola lectura de la página de inicio los animes recientes,
+// los episodios recientes y los animes en emisión.
+// Retorna un *errs.ParseError solo si el documento no es una página de inicio utilizable
+// (falta la cuadrícula de animes); una sección sin elementos queda vacía, de modo que un
+// cambio en una sección no afecte a las demás.
+func (p *Parser) ParseHome(htmlElement io.Reader) (dto.HomeSnapshot, error) {
+	doc, err := goquery.NewDocumentFromReader(htmlElement)
+	if err != nil {
+		return dto.HomeSnapshot{}, &errs.ParseError{Page: errs.PageHome, Field: "html", Err: err}
+	}
+
+	animes, err := p.animeListFromDocument(doc, errs.PageHome)
+	if err != nil {
+		return dto.HomeSnapshot{}, err
 	}
+	episodes, _ := p.recentEpisodesFromDocument(doc)
+	onAir, _ := p.onAirFromDocument(doc)
 
-	return results, nil
+	return p.mapper.ToHomeSnapshot(animes.Animes, episodes, onAir), nil
+}
+
+// ParseOnAir extrae la lista de animes en emisión de la barra lateral de la página de inicio.
+// El título es el texto del enlace sin la etiqueta de tipo.
+// Retorna un *errs.ParseError si la barra lateral no contiene animes.
+func (p *Parser) ParseOnAir(htmlElement io.Reader) ([]dto.AnimeRef, error) {
+	doc, err := goquery.NewDocumentFromReader(htmlElement)
+	if err != nil {
+		return nil, &errs.ParseError{Page: errs.PageHome, Field: "html", Err: err}
+	}
+
+	return p.onAirFromDocument(doc)
+}
+
+// onAirFromDocument extrae los animes en emisión de un documento ya parseado.
+func (p *Parser) onAirFromDocument(doc *goquery.Document) ([]dto.AnimeRef, error) {
+	result := []dto.AnimeRef{}
+	doc.Find(selectorOnAirItem).Each(func(_ int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		id, err := extractID(href)
+		if err != nil || id == "" {
+			return
+		}
+
+		tipo := strings.TrimSpace(s.Find(selectorOnAirType).Text())
+		title := strings.TrimSpace(s.Clone().Children().Remove().End().Text())
+
+		result = append(result, p.mapper.ToAnimeRef(id, title, tipo))
+	})
+
+	if len(result) == 0 {
+		return result, &errs.ParseError{Page: errs.PageHome, Field: "onair"}
+	}
+	return result, nil
+}
+
+// ParseGenres extrae el catálogo de géneros del formulario de filtros de /browse.
+// Cada opción aporta el slug aceptado por el filtro y el nombre visible del género.
+// Retorna un *errs.ParseError si el formulario no contiene géneros.
+func (p *Parser) ParseGenres(htmlElement io.Reader) ([]dto.Genre, error) {
+	doc, err := goquery.NewDocumentFromReader(htmlElement)
+	if err != nil {
+		return nil, &errs.ParseError{Page: errs.PageBrowse, Field: "html", Err: err}
+	}
+
+	genres := []dto.Genre{}
+	doc.Find(selectorGenreOption).Each(func(_ int, s *goquery.Selection) {
+		slug, _ := s.Attr("value")
+		name := strings.TrimSpace(s.Text())
+		if slug == "" || name == "" {
+			return
+		}
+		genres = append(genres, p.mapper.ToGenre(slug, name))
+	})
+
+	if len(genres) == 0 {
+		return genres, &errs.ParseError{Page: errs.PageBrowse, Field: "genres"}
+	}
+	return genres, nil
 }
 
 // ParseAnimeInfo extrae información completa de un anime específico.
@@ -119,12 +236,13 @@ func (p *Parser) ParseAnimeWithPagination(htmlElement io.Reader) (dto.AnimeRespo
 // - Información básica (título, sinopsis, tipo, puntuación, imagen)
 // - Géneros del anime
 // - Estado de emisión y fecha del próximo episodio
-// - Lista de episodios disponibles
+// - Lista de episodios disponibles con su ID, enlace y miniatura
 // - Animes relacionados (secuelas, precuelas, spin-offs)
+// - Títulos alternativos, número de votos, seguidores e imagen de portada
 func (p *Parser) ParseAnimeInfo(htmlElement io.Reader, idAnime string) (dto.AnimeInfoResponse, error) {
 	doc, err := goquery.NewDocumentFromReader(htmlElement)
 	if err != nil {
-		return dto.AnimeInfoResponse{}, fmt.Errorf("error al crear documento desde HTML: %w", err)
+		return dto.AnimeInfoResponse{}, &errs.ParseError{Page: errs.PageAnimeInfo, Field: "html", Err: err}
 	}
 
 	result := &ParseResult{}
@@ -133,14 +251,15 @@ func (p *Parser) ParseAnimeInfo(htmlElement io.Reader, idAnime string) (dto.Anim
 		scriptContent := s.Text()
 
 		if strings.Contains(scriptContent, "var episodes") {
-			episodes, nextEpisode, err := episodeInfo(scriptContent)
+			episodes, animeID, nextEpisode, err := episodeInfo(scriptContent)
 			if err != nil {
-				result.episodes = []int{}
+				result.episodes = []EpisodeRef{}
 				result.nextEpisode = ""
 				return
 			}
 
 			result.episodes = episodes
+			result.animeID = animeID
 			result.nextEpisode = nextEpisode
 		}
 	})
@@ -154,6 +273,16 @@ func (p *Parser) ParseAnimeInfo(htmlElement io.Reader, idAnime string) (dto.Anim
 			result.genres = append(result.genres, genreSel.Text())
 		})
 
+		s.Find(selectorInfoAltTitles).Each(func(_ int, altSel *goquery.Selection) {
+			if alt := strings.TrimSpace(altSel.Text()); alt != "" {
+				result.altTitles = append(result.altTitles, alt)
+			}
+		})
+		result.votes, _ = strconv.Atoi(strings.TrimSpace(s.Find(selectorInfoVotes).First().Text()))
+		result.followers, _ = strconv.Atoi(strings.TrimSpace(s.Find(selectorInfoFollowers).First().Text()))
+		bannerStyle, _ := s.Find(selectorInfoBanner).Attr("style")
+		result.banner = extractBackgroundURL(bannerStyle)
+
 		sinopsis, _ := s.Find(selectorInfoSynopsis).Html()
 		result.sipnopsis = html.UnescapeString(sinopsis)
 		result.status, _ = s.Find(selectorInfoStatus).Html()
@@ -186,6 +315,7 @@ func (p *Parser) ParseAnimeInfo(htmlElement io.Reader, idAnime string) (dto.Anim
 				ID:       id,
 				Title:    title,
 				Category: relationType,
+				Type:     classifyRelation(relationType),
 			})
 		})
 	})
@@ -200,12 +330,16 @@ func (p *Parser) ParseAnimeInfo(htmlElement io.Reader, idAnime string) (dto.Anim
 		result.animeRelated,
 		result.genres,
 		result.status,
-		result.episodes,
+		p.mapper.ToEpisodes(idAnime, result.animeID, result.episodes),
 		result.nextEpisode,
+		result.altTitles,
+		result.votes,
+		result.followers,
+		result.banner,
 	)
 
 	if len(resultFinal.Title) == 0 {
-		return dto.AnimeInfoResponse{}, fmt.Errorf("no se pudo parsear la información del anime del HTML proporcionado")
+		return dto.AnimeInfoResponse{}, &errs.ParseError{Page: errs.PageAnimeInfo, Field: "title"}
 	}
 
 	return resultFinal, nil
@@ -213,11 +347,13 @@ func (p *Parser) ParseAnimeInfo(htmlElement io.Reader, idAnime string) (dto.Anim
 
 // ParseLinks extrae los enlaces de reproducción de un episodio.
 // Analiza scripts JavaScript embebidos para obtener URLs de múltiples servidores
-// de video (Zippyshare, Mega, etc.) junto con sus códigos de embed.
+// de video (Zippyshare, Mega, etc.) junto con sus códigos de embed, y la tabla de
+// descargas con el servidor, formato, idioma y URL de cada archivo. También extrae
+// los identificadores numéricos del anime y del episodio y los episodios anterior y siguiente.
 func (p *Parser) ParseLinks(htmlElement io.Reader, idAnime string, episodeNum uint) (dto.LinkResponse, error) {
 	doc, err := goquery.NewDocumentFromReader(htmlElement)
 	if err != nil {
-		return dto.LinkResponse{}, fmt.Errorf("error al crear documento desde HTML: %w", err)
+		return dto.LinkResponse{}, &errs.ParseError{Page: errs.PageEpisode, Field: "html", Err: err}
 	}
 
 	result := &ParseEpisodeLinksResult{
@@ -227,6 +363,9 @@ func (p *Parser) ParseLinks(htmlElement io.Reader, idAnime string, episodeNum ui
 
 	doc.Find("script[type=\"text/javascript\"]").Each(func(_ int, s *goquery.Selection) {
 		scriptContent := s.Text()
+		if strings.Contains(scriptContent, "var episode_id") {
+			result.meta.AnimeID, result.meta.EpisodeID = scriptEpisodeIDs(scriptContent)
+		}
 		if strings.Contains(scriptContent, "var videos") {
 			links, err := scriptLinksEpisode(scriptContent)
 			if err != nil {
@@ -240,11 +379,41 @@ func (p *Parser) ParseLinks(htmlElement io.Reader, idAnime string, episodeNum ui
 		result.Title, _ = s.Find(selectorInfoTitle).Html()
 	})
 
+	result.meta.PrevEpisode = navigationEpisode(doc.Find(selectorEpisodePrev))
+	result.meta.NextEpisode = navigationEpisode(doc.Find(selectorEpisodeNext))
+
+	doc.Find(selectorDownloadRow).Each(func(_ int, s *goquery.Selection) {
+		cells := s.Find(selectorDownloadCell)
+		href, ok := cells.Eq(3).Find(selectorArticleLink).Attr("href")
+		if cells.Length() < 4 || !ok || href == "" {
+			return
+		}
+		server := strings.TrimSpace(cells.Eq(0).Text())
+		format := strings.TrimSpace(cells.Eq(1).Text())
+		language := dto.Language(strings.TrimSpace(cells.Eq(2).Text()))
+
+		result.downloads = append(result.downloads, p.mapper.ToDownload(server, format, language, href))
+	})
+
 	if len(result.links) == 0 {
-		return dto.LinkResponse{}, fmt.Errorf("no se pudo parsear los enlaces del episodio del HTML proporcionado")
+		return dto.LinkResponse{}, &errs.ParseError{Page: errs.PageEpisode, Field: "videos"}
 	}
 
-	return p.mapper.ToLinkEpisode(result.ID, result.Title, result.Episode, result.links), nil
+	return p.mapper.ToLinkEpisode(result.ID, result.Title, result.Episode, result.meta, result.links, result.downloads), nil
+}
+
+// navigationEpisode retorna el número de episodio enlazado por un botón de navegación,
+// o 0 si el botón no existe o su enlace no termina en un número de episodio.
+func navigationEpisode(s *goquery.Selection) uint {
+	href, ok := s.First().Attr("href")
+	if !ok {
+		return 0
+	}
+	episode, err := extractEpisodeNumber(href)
+	if err != nil || episode <= 0 {
+		return 0
+	}
+	return uint(episode)
 }
 
 // ParseRecentEpisode extrae la lista de episodios recientemente publicados.
@@ -253,8 +422,14 @@ func (p *Parser) ParseLinks(htmlElement io.Reader, idAnime string, episodeNum ui
 func (p *Parser) ParseRecentEpisode(htmlElement io.Reader) ([]dto.EpisodeListResponse, error) {
 	doc, err := goquery.NewDocumentFromReader(htmlElement)
 	if err != nil {
-		return []dto.EpisodeListResponse{}, fmt.Errorf("error al crear documento desde HTML: %w", err)
+		return []dto.EpisodeListResponse{}, &errs.ParseError{Page: errs.PageHome, Field: "html", Err: err}
 	}
+
+	return p.recentEpisodesFromDocument(doc)
+}
+
+// recentEpisodesFromDocument extrae los episodios recientes de un documento ya parseado.
+func (p *Parser) recentEpisodesFromDocument(doc *goquery.Document) ([]dto.EpisodeListResponse, error) {
 	result := []dto.EpisodeListResponse{}
 
 	doc.Find(selectorEpisodeList).Each(func(_ int, s *goquery.Selection) {
@@ -278,7 +453,7 @@ func (p *Parser) ParseRecentEpisode(htmlElement io.Reader) ([]dto.EpisodeListRes
 	})
 
 	if len(result) == 0 {
-		return result, fmt.Errorf("no se encontraron episodios recientes en el HTML proporcionado")
+		return result, &errs.ParseError{Page: errs.PageHome, Field: "episodes"}
 	}
 	return result, nil
 }
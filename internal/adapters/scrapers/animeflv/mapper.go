@@ -5,7 +5,15 @@
 // proporcionando una capa de abstracción entre el scraping y la lógica de negocio.
 package animeflv
 
-import "github.com/dst3v3n/api-anime/internal/domain/dto"
+import (
+	"fmt"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+)
+
+// episodeThumbnailURL es el formato de la miniatura de un episodio en el CDN del sitio:
+// ID numérico del anime y número del episodio.
+const episodeThumbnailURL = "https://cdn.animeflv.net/screenshots/%d/%d/th_3.jpg"
 
 // Maper es el componente encargado de transformar datos a DTOs.
 type Maper struct{}
@@ -29,8 +37,9 @@ func (m *Maper) ToAnime(ID string, Title string, Sinopsis string, Tipo string, P
 }
 
 // ToAnimeInfo transforma datos completos de anime en un DTO AnimeInfoResponse.
-// Combina información básica con datos adicionales como géneros, episodios y animes relacionados.
-func (m *Maper) ToAnimeInfo(ID string, Title string, Sinopsis string, Tipo string, Punctuation float64, Image string, AnimeRelated []dto.AnimeRelated, Generos []string, Estado string, Episodes []int, NextEpisode string) dto.AnimeInfoResponse {
+// Combina información básica con datos adicionales como géneros, episodios, animes relacionados,
+// títulos alternativos, votos, seguidores y banner.
+func (m *Maper) ToAnimeInfo(ID string, Title string, Sinopsis string, Tipo string, Punctuation float64, Image string, AnimeRelated []dto.AnimeRelated, Generos []string, Estado string, Episodes []dto.Episode, NextEpisode string, AltTitles []string, Votes int, Followers int, Banner string) dto.AnimeInfoResponse {
 	return dto.AnimeInfoResponse{
 		AnimeStruct: dto.AnimeStruct{
 			ID:          ID,
@@ -45,26 +54,92 @@ func (m *Maper) ToAnimeInfo(ID string, Title string, Sinopsis string, Tipo strin
 		Status:       dto.StatusAnime(Estado),
 		NextEpisode:  NextEpisode,
 		Episodes:     Episodes,
+		AltTitles:    AltTitles,
+		Votes:        Votes,
+		Followers:    Followers,
+		Banner:       Banner,
+	}
+}
+
+// ToEpisodes transforma los episodios de "var episodes" en DTOs Episode.
+// Construye la ruta de cada episodio a partir del ID del anime y, si se conoce el ID
+// numérico del anime, la URL de su miniatura en el CDN.
+func (m *Maper) ToEpisodes(IDAnime string, AnimeID int, Episodes []EpisodeRef) []dto.Episode {
+	episodes := make([]dto.Episode, 0, len(Episodes))
+	for _, ref := range Episodes {
+		episode := dto.Episode{
+			Number: ref.Number,
+			ID:     ref.ID,
+			URL:    fmt.Sprintf("/ver/%s-%d", IDAnime, ref.Number),
+		}
+		if AnimeID > 0 {
+			episode.ThumbnailURL = fmt.Sprintf(episodeThumbnailURL, AnimeID, ref.Number)
+		}
+		episodes = append(episodes, episode)
+	}
+	return episodes
+}
+
+// ToHomeSnapshot agrupa las secciones de la página de inicio en un DTO HomeSnapshot.
+func (m *Maper) ToHomeSnapshot(RecentAnime []dto.AnimeStruct, RecentEpisodes []dto.EpisodeListResponse, OnAir []dto.AnimeRef) dto.HomeSnapshot {
+	return dto.HomeSnapshot{
+		RecentAnime:    RecentAnime,
+		RecentEpisodes: RecentEpisodes,
+		OnAir:          OnAir,
+	}
+}
+
+// ToAnimeRef transforma una entrada de un listado lateral en un DTO AnimeRef.
+func (m *Maper) ToAnimeRef(ID string, Title string, Tipo string) dto.AnimeRef {
+	return dto.AnimeRef{
+		ID:    ID,
+		Title: Title,
+		Type:  dto.CategoryAnime(Tipo),
+	}
+}
+
+// ToGenre transforma una opción del filtro de géneros en un DTO Genre.
+func (m *Maper) ToGenre(Slug string, Name string) dto.Genre {
+	return dto.Genre{
+		Slug: Slug,
+		Name: Name,
 	}
 }
 
 // ToLinks transforma datos de un servidor de video en un DTO LinkSource.
-func (m *Maper) ToLinks(Server string, URL string, Code string) dto.LinkSource {
+func (m *Maper) ToLinks(Server string, URL string, Code string, Language dto.Language) dto.LinkSource {
 	return dto.LinkSource{
-		Server: Server,
-		URL:    URL,
-		Code:   Code,
+		Server:   Server,
+		URL:      URL,
+		Code:     Code,
+		Language: Language,
+	}
+}
+
+// ToDownload transforma una fila de la tabla de descargas en un DTO DownloadSource.
+func (m *Maper) ToDownload(Server string, Format string, Language dto.Language, URL string) dto.DownloadSource {
+	return dto.DownloadSource{
+		Server:   Server,
+		Format:   Format,
+		Language: Language,
+		URL:      URL,
 	}
 }
 
 // ToLinkEpisode transforma datos de enlaces de episodio en un DTO LinkResponse.
-// Agrupa todos los enlaces de diferentes servidores para un episodio específico.
-func (m *Maper) ToLinkEpisode(ID string, Title string, Episode uint, Links []dto.LinkSource) dto.LinkResponse {
+// Agrupa todos los enlaces de diferentes servidores, las descargas y los metadatos
+// de navegación para un episodio específico.
+func (m *Maper) ToLinkEpisode(ID string, Title string, Episode uint, Meta EpisodeMeta, Links []dto.LinkSource, Downloads []dto.DownloadSource) dto.LinkResponse {
 	return dto.LinkResponse{
-		ID:      ID,
-		Episode: Episode,
-		Title:   Title,
-		Link:    Links,
+		ID:          ID,
+		Episode:     Episode,
+		Title:       Title,
+		AnimeID:     Meta.AnimeID,
+		EpisodeID:   Meta.EpisodeID,
+		PrevEpisode: Meta.PrevEpisode,
+		NextEpisode: Meta.NextEpisode,
+		Link:        Links,
+		Downloads:   Downloads,
 	}
 }
 
@@ -0,0 +1,70 @@
+// Package animeflv - mirrors.go
+// Este archivo implementa el seguimiento de salud de los hosts del sitio (URL base y mirrors).
+// Un host que falla por error de conexión o responde 5xx se marca como caído y se omite
+// durante un periodo de enfriamiento, de modo que las peticiones siguientes van directamente
+// al siguiente host disponible.
+package animeflv
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// mirrorPool lleva el estado de salud de los hosts del sitio en orden de preferencia.
+type mirrorPool struct {
+	mu        sync.Mutex
+	hosts     []string
+	cooldown  time.Duration
+	downUntil map[string]time.Time
+	now       func() time.Time
+}
+
+// newMirrorPool crea el conjunto de hosts a partir de las URLs base en orden de preferencia.
+func newMirrorPool(hosts []string, cooldown time.Duration) *mirrorPool {
+	return &mirrorPool{
+		hosts:     hosts,
+		cooldown:  cooldown,
+		downUntil: make(map[string]time.Time),
+		now:       time.Now,
+	}
+}
+
+// candidates retorna los hosts a intentar para una petición: los sanos en orden de
+// preferencia. Si todos están en enfriamiento, retorna todos ordenados por el fin de su
+// enfriamiento para que la petición no falle sin haberse intentado.
+func (p *mirrorPool) candidates() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	healthy := make([]string, 0, len(p.hosts))
+	for _, host := range p.hosts {
+		if !now.Before(p.downUntil[host]) {
+			healthy = append(healthy, host)
+		}
+	}
+	if len(healthy) > 0 {
+		return healthy
+	}
+
+	cooling := slices.Clone(p.hosts)
+	slices.SortStableFunc(cooling, func(a, b string) int {
+		return p.downUntil[a].Compare(p.downUntil[b])
+	})
+	return cooling
+}
+
+// markDown marca host como caído durante el periodo de enfriamiento.
+func (p *mirrorPool) markDown(host string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.downUntil[host] = p.now().Add(p.cooldown)
+}
+
+// markUp marca host como sano tras una respuesta válida.
+func (p *mirrorPool) markUp(host string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.downUntil, host)
+}
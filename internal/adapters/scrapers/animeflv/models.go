@@ -1,22 +1,14 @@
 // Package animeflv - models.go
 // Este archivo define las estructuras de datos internas utilizadas específicamente
 // por el scraper de AnimeFlv. Incluye:
-// - Config: Configuración de URLs del sitio
 // - ParseResult: Estructura temporal para almacenar datos durante el parsing de información de anime
 // - ParseEpisodeLinksResult: Estructura temporal para almacenar enlaces de episodios
+// - EpisodeMeta: Identificadores y navegación de la página de un episodio
 // - VideoServer y Videos: Estructuras para deserializar JSON embebido en scripts del sitio
 package animeflv
 
 import "github.com/dst3v3n/api-anime/internal/domain/dto"
 
-// Config contiene la configuración de URLs del sitio AnimeFlv.
-type Config struct {
-	BaseURL       string // URL base del sitio (ej: "https://www3.animeflv.net")
-	SearchURL     string // URL del endpoint de búsqueda
-	AnimeInfoURL  string // URL del endpoint de información de anime
-	VerEpisodeURL string // URL base para ver episodios
-}
-
 // ParseResult almacena temporalmente los datos extraídos durante el parsing de información de anime.
 // Se utiliza como estructura intermedia antes de convertir a AnimeInfoResponse.
 type ParseResult struct {
@@ -28,17 +20,40 @@ type ParseResult struct {
 	punctuacion  float64            // Calificación del anime
 	animeRelated []dto.AnimeRelated // Animes relacionados
 	genres       []string           // Géneros del anime
-	episodes     []int              // Lista de episodios disponibles
+	animeID      int                // Identificador numérico del anime ("var anime_info")
+	episodes     []EpisodeRef       // Lista de episodios disponibles
 	nextEpisode  string             // Fecha del próximo episodio
+	altTitles    []string           // Títulos alternativos
+	votes        int                // Número de votos
+	followers    int                // Número de seguidores
+	banner       string             // URL del banner
 }
 
 // ParseEpisodeLinksResult almacena temporalmente los enlaces extraídos de un episodio.
 // Se utiliza como estructura intermedia antes de convertir a LinkResponse.
 type ParseEpisodeLinksResult struct {
-	ID      string           // Identificador del anime
-	Title   string           // Título del anime
-	Episode uint             // Número del episodio
-	links   []dto.LinkSource // Enlaces de reproducción disponibles
+	ID        string               // Identificador del anime
+	Title     string               // Título del anime
+	Episode   uint                 // Número del episodio
+	meta      EpisodeMeta          // Identificadores numéricos y navegación del episodio
+	links     []dto.LinkSource     // Enlaces de reproducción disponibles
+	downloads []dto.DownloadSource // Enlaces de la tabla de descargas
+}
+
+// EpisodeMeta almacena los metadatos de la página de un episodio: los identificadores
+// numéricos de las variables JavaScript y los episodios vecinos de la navegación.
+type EpisodeMeta struct {
+	AnimeID     int  // Valor de "var anime_id"
+	EpisodeID   int  // Valor de "var episode_id"
+	PrevEpisode uint // Episodio enlazado por el botón "ANTERIOR"; 0 si no existe
+	NextEpisode uint // Episodio enlazado por el botón "SIGUIENTE"; 0 si no existe
+}
+
+// EpisodeRef representa un elemento de la variable "var episodes": el número del episodio
+// y su identificador numérico en el sitio.
+type EpisodeRef struct {
+	Number int // Número del episodio
+	ID     int // Identificador numérico del episodio
 }
 
 // VideoServer representa un servidor de video individual con sus propiedades.
@@ -53,7 +68,6 @@ type VideoServer struct {
 }
 
 // Videos contiene la colección de servidores de video organizados por idioma.
-// La estructura refleja el formato JSON del sitio AnimeFlv.
-type Videos struct {
-	SUB []VideoServer `json:"SUB"` // Servidores de video subtitulados
-}
+// La estructura refleja el formato JSON del sitio AnimeFlv, donde cada clave es un
+// idioma ("SUB" para subtitulados, "LAT" para doblaje latino, etc.).
+type Videos map[string][]VideoServer
@@ -0,0 +1,167 @@
+// Package animeflv - ratelimit.go
+// Este archivo implementa los límites de peticiones al sitio. Todas las peticiones comparten
+// un límite global y, dentro de él, cada clase de operación (búsquedas, fichas, episodios y
+// página principal) tiene su propio límite configurable.
+// Cuando el sitio responde 429 el límite de la clase se reduce a la mitad (sin bajar del
+// 10% del configurado) y, por cada intervalo de recuperación sin nuevos 429, recupera un
+// 10% del ritmo configurado hasta volver a él.
+package animeflv
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dst3v3n/api-anime/internal/config"
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"golang.org/x/time/rate"
+)
+
+const (
+	rateSlowdownFactor = 0.5 // Fracción del ritmo efectivo que se conserva tras un 429
+	rateMinFraction    = 0.1 // Ritmo mínimo, como fracción del configurado
+	rateRecoveryStep   = 0.1 // Fracción del ritmo configurado recuperada por intervalo
+)
+
+// requestClasses son las clases de operación en el orden en que se reportan sus límites.
+var requestClasses = []dto.RequestClass{dto.RequestBrowse, dto.RequestInfo, dto.RequestEpisode, dto.RequestHome}
+
+// requestClass retorna la clase de operación de una ruta del sitio.
+func requestClass(path string) dto.RequestClass {
+	switch {
+	case strings.HasPrefix(path, searchPath):
+		return dto.RequestBrowse
+	case strings.HasPrefix(path, animeInfoPath):
+		return dto.RequestInfo
+	case strings.HasPrefix(path, verEpisodePath):
+		return dto.RequestEpisode
+	default:
+		return dto.RequestHome
+	}
+}
+
+// adaptiveLimiter es el límite de peticiones de una clase de operación, que se reduce tras
+// un 429 y se recupera gradualmente.
+type adaptiveLimiter struct {
+	mu         sync.Mutex
+	limiter    *rate.Limiter
+	configured rate.Limit
+	reduced    rate.Limit // Ritmo fijado por el último 429
+	slowedAt   time.Time  // Momento del último 429; cero si el ritmo está completo
+	recovery   time.Duration
+	now        func() time.Time
+}
+
+// newAdaptiveLimiter crea el límite de una clase de operación. Un límite sin ritmo usa
+// config.DefaultRateLimit, y un intervalo de recuperación de 0 desactiva el ajuste tras los 429.
+func newAdaptiveLimiter(limit config.RateLimit, recovery time.Duration) *adaptiveLimiter {
+	if limit.Rate <= 0 {
+		limit = config.DefaultRateLimit
+	}
+	configured := rate.Limit(limit.Rate)
+	return &adaptiveLimiter{
+		limiter:    rate.NewLimiter(configured, max(limit.Burst, 1)),
+		configured: configured,
+		recovery:   recovery,
+		now:        time.Now,
+	}
+}
+
+// wait bloquea hasta que el límite permita una petición o ctx termine.
+func (l *adaptiveLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	if effective := l.effective(); effective != l.limiter.Limit() {
+		l.limiter.SetLimit(effective)
+	}
+	l.mu.Unlock()
+
+	return l.limiter.Wait(ctx)
+}
+
+// slowDown reduce el ritmo tras un 429: lo deja en la mitad del ritmo efectivo actual,
+// sin bajar del 10% del configurado.
+func (l *adaptiveLimiter) slowDown() {
+	if l.recovery <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reduced = max(l.effective()*rateSlowdownFactor, l.configured*rateMinFraction)
+	l.slowedAt = l.now()
+	l.limiter.SetLimit(l.reduced)
+}
+
+// effective retorna el ritmo efectivo actual: el reducido por el último 429 más un 10% del
+// configurado por cada intervalo de recuperación transcurrido, hasta el configurado. Al
+// recuperar el ritmo completo olvida el último 429. Debe invocarse con mu tomado.
+func (l *adaptiveLimiter) effective() rate.Limit {
+	if l.slowedAt.IsZero() {
+		return l.configured
+	}
+
+	steps := l.now().Sub(l.slowedAt) / l.recovery
+	effective := l.reduced + l.configured*rateRecoveryStep*rate.Limit(steps)
+	if effective >= l.configured {
+		l.slowedAt = time.Time{}
+		return l.configured
+	}
+	return effective
+}
+
+// state retorna el estado del límite para la clase indicada.
+func (l *adaptiveLimiter) state(class dto.RequestClass) dto.RateLimitState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	effective := l.effective()
+	return dto.RateLimitState{
+		Class:          class,
+		Rate:           float64(effective),
+		ConfiguredRate: float64(l.configured),
+		Burst:          l.limiter.Burst(),
+		Throttled:      effective < l.configured,
+	}
+}
+
+// rateLimiters agrupa el límite global y los límites de todas las clases de operación.
+type rateLimiters struct {
+	global  *adaptiveLimiter // Compartido por todas las clases; no se ajusta tras los 429
+	classes map[dto.RequestClass]*adaptiveLimiter
+}
+
+// newRateLimiters crea el límite global y los de cada clase de operación a partir de la configuración.
+func newRateLimiters(cfg *config.Config) rateLimiters {
+	return rateLimiters{
+		global: newAdaptiveLimiter(cfg.GlobalRateLimit, 0),
+		classes: map[dto.RequestClass]*adaptiveLimiter{
+			dto.RequestBrowse:  newAdaptiveLimiter(cfg.BrowseRateLimit, cfg.RateRecoveryInterval),
+			dto.RequestInfo:    newAdaptiveLimiter(cfg.InfoRateLimit, cfg.RateRecoveryInterval),
+			dto.RequestEpisode: newAdaptiveLimiter(cfg.EpisodeRateLimit, cfg.RateRecoveryInterval),
+			dto.RequestHome:    newAdaptiveLimiter(cfg.HomeRateLimit, cfg.RateRecoveryInterval),
+		},
+	}
+}
+
+// class retorna el límite de la clase de operación de una ruta del sitio.
+func (r rateLimiters) class(path string) *adaptiveLimiter {
+	return r.classes[requestClass(path)]
+}
+
+// wait bloquea hasta que el límite de la clase y luego el global permitan una petición,
+// o hasta que ctx termine.
+func (r rateLimiters) wait(ctx context.Context, class *adaptiveLimiter) error {
+	if err := class.wait(ctx); err != nil {
+		return err
+	}
+	return r.global.wait(ctx)
+}
+
+// states retorna el estado del límite de cada clase de operación.
+func (r rateLimiters) states() []dto.RateLimitState {
+	states := make([]dto.RateLimitState, 0, len(requestClasses))
+	for _, class := range requestClasses {
+		states = append(states, r.classes[class].state(class))
+	}
+	return states
+}
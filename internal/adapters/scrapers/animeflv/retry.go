@@ -0,0 +1,109 @@
+// Package animeflv - retry.go
+// Este archivo implementa la política de reintentos de las peticiones al sitio.
+// Los fallos transitorios (errores de conexión, 5xx y 429) se reintentan con espera
+// exponencial y jitter, respetando la cabecera Retry-After y el deadline del contexto.
+// Los 404 y el resto de 4xx nunca se reintentan.
+package animeflv
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dst3v3n/api-anime/internal/config"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+)
+
+// retryPolicy define cuántas veces y con qué espera se repite una petición fallida.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+}
+
+// newRetryPolicy crea la política de reintentos a partir de la configuración.
+// Un número de intentos menor que 1 equivale a no reintentar.
+func newRetryPolicy(cfg *config.Config) retryPolicy {
+	return retryPolicy{
+		maxAttempts: max(cfg.MaxAttempts, 1),
+		baseDelay:   cfg.RetryBaseDelay,
+		maxDelay:    cfg.RetryMaxDelay,
+	}
+}
+
+// retryable reporta si err es un fallo transitorio que vale la pena reintentar:
+// un error de conexión, un 429 o un 500, 502, 503 o 504.
+func retryable(err error) bool {
+	var statusErr *errs.UpstreamStatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.Code {
+		case http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// delay retorna la espera antes del intento siguiente a attempt (empezando en 1).
+// Si el sitio indicó Retry-After se usa esa espera; si supera la espera máxima retorna
+// false para no bloquear al consumidor. En otro caso la espera crece exponencialmente
+// desde baseDelay hasta maxDelay, con un jitter aleatorio entre la mitad y el total.
+func (p retryPolicy) delay(attempt int, err error) (time.Duration, bool) {
+	var statusErr *errs.UpstreamStatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return statusErr.RetryAfter, statusErr.RetryAfter <= p.maxDelay
+	}
+
+	backoff := p.baseDelay << (attempt - 1)
+	if backoff > p.maxDelay || backoff <= 0 {
+		backoff = p.maxDelay
+	}
+	if backoff <= 0 {
+		return 0, true
+	}
+	return backoff/2 + rand.N(backoff/2+1), true
+}
+
+// waitRetry espera d antes de reintentar. Retorna false sin esperar si el deadline de
+// ctx vence antes de terminar la espera, o si ctx se cancela durante ella.
+func waitRetry(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return false
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// parseRetryAfter interpreta la cabecera Retry-After, expresada en segundos o como fecha HTTP.
+// Retorna 0 si la cabecera no existe, no es válida o indica una fecha pasada.
+func parseRetryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
@@ -5,21 +5,25 @@
 // - Lista de episodios disponibles
 // - Información de próximos episodios
 // - Enlaces de servidores de video para reproducción
+// - Identificadores numéricos del anime y del episodio
 package animeflv
 
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
+	"strconv"
 
 	"github.com/dst3v3n/api-anime/internal/domain/dto"
 )
 
 // scriptEpisodeList extrae la lista de episodios desde una variable JavaScript.
 // Busca y parsea la variable "var episodes = [[...]]" que contiene un array bidimensional
-// donde cada elemento tiene [episodeNumber, id]. Retorna solo los números de episodio.
-func scriptEpisodeList(scriptContent string) ([]int, error) {
-	var episodios []int
+// donde cada elemento tiene [episodeNumber, id]. Retorna el número y el ID de cada episodio.
+func scriptEpisodeList(scriptContent string) ([]EpisodeRef, error) {
+	var episodios []EpisodeRef
 	episodesRegex := regexp.MustCompile(`var episodes = (\[\[.*?\]\]);`)
 	if matches := episodesRegex.FindStringSubmatch(scriptContent); len(matches) > 1 {
 		var episodes [][]int
@@ -28,7 +32,7 @@ func scriptEpisodeList(scriptContent string) ([]int, error) {
 		}
 		for _, ep := range episodes {
 			if len(ep) >= 2 {
-				episodios = append(episodios, ep[0])
+				episodios = append(episodios, EpisodeRef{Number: ep[0], ID: ep[1]})
 			}
 		}
 	}
@@ -37,26 +41,34 @@ func scriptEpisodeList(scriptContent string) ([]int, error) {
 
 // scriptInfo extrae información adicional del anime desde una variable JavaScript.
 // Busca y parsea la variable "var anime_info = [...]" que contiene un array con
-// datos del anime. El cuarto elemento (índice 3) contiene la fecha del próximo episodio.
-func scriptInfo(scriptContent string) (string, error) {
+// datos del anime. El primer elemento contiene el ID numérico del anime y el cuarto
+// (índice 3), si existe, la fecha del próximo episodio.
+func scriptInfo(scriptContent string) (int, string, error) {
+	var animeID int
 	var nextEpisode string
 	animeInfoRegex := regexp.MustCompile(`var anime_info = (\[.*?\]);`)
 	if matches := animeInfoRegex.FindStringSubmatch(scriptContent); len(matches) > 1 {
 		var animeInfo []any
 		if err := json.Unmarshal([]byte(matches[1]), &animeInfo); err != nil {
-			return "", fmt.Errorf("error al parsear JSON de información del anime: %w", err)
+			return 0, "", fmt.Errorf("error al parsear JSON de información del anime: %w", err)
+		}
+		if len(animeInfo) >= 1 {
+			if id, ok := animeInfo[0].(string); ok {
+				animeID, _ = strconv.Atoi(id)
+			}
 		}
 		if len(animeInfo) >= 4 {
 			nextEpisode = animeInfo[3].(string)
 		}
 	}
-	return nextEpisode, nil
+	return animeID, nextEpisode, nil
 }
 
 // scriptLinksEpisode extrae los enlaces de video desde una variable JavaScript.
 // Busca y parsea la variable "var videos = {...}" que contiene un objeto JSON con
-// servidores de video (SUB, LAT, etc.). Cada servidor tiene información de URL,
-// código de embed y otras propiedades. Retorna una lista de fuentes de enlaces.
+// servidores de video agrupados por idioma (SUB, LAT, etc.). Cada servidor tiene
+// información de URL, código de embed y otras propiedades. Retorna una lista de fuentes
+// de enlaces de todos los idiomas, con los subtitulados primero y el resto en orden alfabético.
 func scriptLinksEpisode(scriptContent string) ([]dto.LinkSource, error) {
 	toLinkSource := []dto.LinkSource{}
 	linkEpisodeRegex := regexp.MustCompile(`var videos = (\{.*?\});`)
@@ -65,13 +77,35 @@ func scriptLinksEpisode(scriptContent string) ([]dto.LinkSource, error) {
 		if err := json.Unmarshal([]byte(matches[1]), &videos); err != nil {
 			return nil, fmt.Errorf("error al parsear JSON de enlaces de video: %w", err)
 		}
-		for _, linkVideo := range videos.SUB {
-			toLinkSource = append(toLinkSource, dto.LinkSource{
-				Server: linkVideo.Server,
-				URL:    linkVideo.URL,
-				Code:   linkVideo.Code,
-			})
+		languages := slices.Sorted(maps.Keys(videos))
+		if i := slices.Index(languages, string(dto.LanguageSUB)); i > 0 {
+			languages = slices.Insert(slices.Delete(languages, i, i+1), 0, string(dto.LanguageSUB))
+		}
+		for _, language := range languages {
+			for _, linkVideo := range videos[language] {
+				toLinkSource = append(toLinkSource, dto.LinkSource{
+					Server:   linkVideo.Server,
+					URL:      linkVideo.URL,
+					Code:     linkVideo.Code,
+					Language: dto.Language(language),
+				})
+			}
 		}
 	}
 	return toLinkSource, nil
 }
+
+// scriptEpisodeIDs extrae los identificadores numéricos de la página de un episodio.
+// Busca las variables "var anime_id = N;" y "var episode_id = N;". Las variables
+// ausentes se retornan como 0.
+func scriptEpisodeIDs(scriptContent string) (animeID int, episodeID int) {
+	animeIDRegex := regexp.MustCompile(`var anime_id = (\d+);`)
+	if matches := animeIDRegex.FindStringSubmatch(scriptContent); len(matches) > 1 {
+		animeID, _ = strconv.Atoi(matches[1])
+	}
+	episodeIDRegex := regexp.MustCompile(`var episode_id = (\d+);`)
+	if matches := episodeIDRegex.FindStringSubmatch(scriptContent); len(matches) > 1 {
+		episodeID, _ = strconv.Atoi(matches[1])
+	}
+	return animeID, episodeID
+}
@@ -1,13 +1,18 @@
 // Package config carga y gestiona la configuración de la aplicación.
 // Proporciona un singleton de configuración que carga variables de entorno
-// al inicio de la aplicación y las valida. Incluye configuración de caché (Valkey)
-// y logging (Zerolog) con valores por defecto si no están definidas las variables de entorno.
+// al inicio de la aplicación y las valida. Incluye configuración de caché (Valkey),
+// logging (Zerolog) y del sitio consultado por el scraper (URL base, mirrors, reintentos y
+// límites de peticiones), con valores
+// por defecto si no están definidas las variables de entorno.
 package config
 
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 )
 
 // Config contiene la configuración principal de la aplicación.
@@ -15,6 +20,7 @@ type Config struct {
 	AppName string
 	CacheConfig
 	LogConfig
+	ScraperConfig
 }
 
 // CacheConfig contiene la configuración para la conexión a Valkey (caché distribuido).
@@ -34,6 +40,73 @@ type LogConfig struct {
 	LogEnv     string // Entorno de ejecución (development, staging, production)
 }
 
+// ScraperConfig contiene la configuración del sitio consultado por el scraper.
+// Cuando la URL base falla por error de conexión o responde 5xx, el scraper reintenta
+// la petición en los mirrors en orden, y omite durante MirrorCooldown los hosts caídos.
+// Si todos fallan, o el sitio responde 429, la petición se repite hasta MaxAttempts veces
+// con espera exponencial entre RetryBaseDelay y RetryMaxDelay. Tras BreakerThreshold
+// peticiones fallidas consecutivas el circuit breaker se abre y rechaza las peticiones
+// durante BreakerCooldown, tras lo cual deja pasar una petición de prueba.
+// Todas las peticiones comparten GlobalRateLimit y, además, cada clase de operación tiene su
+// propio límite; tras un 429 el límite de la clase se reduce a la mitad y recupera un 10% del
+// ritmo configurado por cada RateRecoveryInterval sin nuevos 429.
+type ScraperConfig struct {
+	BaseURL        string        // URL base del sitio (ej: "https://www3.animeflv.net")
+	Mirrors        []string      // URLs base alternativas, en orden de preferencia
+	MirrorCooldown time.Duration // Tiempo durante el que se omite un host tras fallar
+	MaxAttempts    int           // Número máximo de intentos por petición (1 desactiva los reintentos)
+	RetryBaseDelay time.Duration // Espera antes del primer reintento; se duplica en cada intento
+	RetryMaxDelay  time.Duration // Espera máxima entre intentos, incluida la indicada por Retry-After
+
+	BreakerThreshold int           // Fallos consecutivos que abren el circuito (0 lo desactiva)
+	BreakerCooldown  time.Duration // Tiempo que el circuito permanece abierto antes de probar de nuevo
+
+	GlobalRateLimit      RateLimit     // Límite compartido por todas las peticiones al sitio
+	BrowseRateLimit      RateLimit     // Búsquedas, catálogo y géneros (/browse)
+	InfoRateLimit        RateLimit     // Fichas de anime (/anime/)
+	EpisodeRateLimit     RateLimit     // Enlaces de episodios (/ver/)
+	HomeRateLimit        RateLimit     // Página principal (/)
+	RateRecoveryInterval time.Duration // Intervalo de recuperación tras un 429 (0 desactiva el ajuste automático)
+}
+
+// RateLimit define el límite de peticiones al sitio, global o de una clase de operación.
+// Un límite sin ritmo (Rate 0) usa DefaultRateLimit.
+type RateLimit struct {
+	Rate  float64 // Peticiones por segundo sostenidas
+	Burst int     // Peticiones que pueden realizarse de golpe
+}
+
+// DefaultRateLimit es el límite por defecto, global y de cada clase de operación: 3 peticiones
+// por segundo con ráfagas de hasta 5. Como todas las clases comparten el límite global, por
+// defecto el sitio nunca recibe más de 3 peticiones por segundo en total.
+var DefaultRateLimit = RateLimit{Rate: 3, Burst: 5}
+
+const (
+	// DefaultBaseURL es la URL base del sitio usada si no se configura otra.
+	DefaultBaseURL = "https://www3.animeflv.net"
+
+	// DefaultMirrorCooldown es el tiempo por defecto durante el que se omite un host caído.
+	DefaultMirrorCooldown = 5 * time.Minute
+
+	// DefaultMaxAttempts es el número de intentos por defecto de cada petición al sitio.
+	DefaultMaxAttempts = 3
+
+	// DefaultRetryBaseDelay es la espera por defecto antes del primer reintento.
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+
+	// DefaultRetryMaxDelay es la espera máxima por defecto entre intentos.
+	DefaultRetryMaxDelay = 10 * time.Second
+
+	// DefaultBreakerThreshold es el número por defecto de fallos consecutivos que abren el circuito.
+	DefaultBreakerThreshold = 5
+
+	// DefaultBreakerCooldown es el tiempo por defecto que el circuito permanece abierto.
+	DefaultBreakerCooldown = 30 * time.Second
+
+	// DefaultRateRecoveryInterval es el intervalo por defecto de recuperación del límite tras un 429.
+	DefaultRateRecoveryInterval = 10 * time.Second
+)
+
 var (
 	instance *Config
 	once     sync.Once
@@ -57,6 +130,23 @@ func NewConfigWithDefaults() *Config {
 			LogAppName: "Anime-API",
 			LogEnv:     "development",
 		},
+		ScraperConfig: ScraperConfig{
+			BaseURL:        DefaultBaseURL,
+			MirrorCooldown: DefaultMirrorCooldown,
+			MaxAttempts:    DefaultMaxAttempts,
+			RetryBaseDelay: DefaultRetryBaseDelay,
+			RetryMaxDelay:  DefaultRetryMaxDelay,
+
+			BreakerThreshold: DefaultBreakerThreshold,
+			BreakerCooldown:  DefaultBreakerCooldown,
+
+			GlobalRateLimit:      DefaultRateLimit,
+			BrowseRateLimit:      DefaultRateLimit,
+			InfoRateLimit:        DefaultRateLimit,
+			EpisodeRateLimit:     DefaultRateLimit,
+			HomeRateLimit:        DefaultRateLimit,
+			RateRecoveryInterval: DefaultRateRecoveryInterval,
+		},
 	}
 }
 
@@ -92,6 +182,24 @@ func NewConfigFromEnvPath(envPath string) (*Config, error) {
 			LogAppName: getEnv("LOG_APP_NAME", "MyApp"),
 			LogEnv:     getEnv("LOG_ENV", "development"),
 		},
+		ScraperConfig: ScraperConfig{
+			BaseURL:        getEnv("SCRAPER_BASE_URL", DefaultBaseURL),
+			Mirrors:        getEnvAsList("SCRAPER_MIRRORS", nil),
+			MirrorCooldown: getEnvAsDuration("SCRAPER_MIRROR_COOLDOWN", DefaultMirrorCooldown),
+			MaxAttempts:    getEnvAsInt("SCRAPER_MAX_ATTEMPTS", DefaultMaxAttempts),
+			RetryBaseDelay: getEnvAsDuration("SCRAPER_RETRY_BASE_DELAY", DefaultRetryBaseDelay),
+			RetryMaxDelay:  getEnvAsDuration("SCRAPER_RETRY_MAX_DELAY", DefaultRetryMaxDelay),
+
+			BreakerThreshold: getEnvAsInt("SCRAPER_BREAKER_THRESHOLD", DefaultBreakerThreshold),
+			BreakerCooldown:  getEnvAsDuration("SCRAPER_BREAKER_COOLDOWN", DefaultBreakerCooldown),
+
+			GlobalRateLimit:      getEnvAsRateLimit("GLOBAL", DefaultRateLimit),
+			BrowseRateLimit:      getEnvAsRateLimit("BROWSE", DefaultRateLimit),
+			InfoRateLimit:        getEnvAsRateLimit("INFO", DefaultRateLimit),
+			EpisodeRateLimit:     getEnvAsRateLimit("EPISODE", DefaultRateLimit),
+			HomeRateLimit:        getEnvAsRateLimit("HOME", DefaultRateLimit),
+			RateRecoveryInterval: getEnvAsDuration("SCRAPER_RATE_RECOVERY_INTERVAL", DefaultRateRecoveryInterval),
+		},
 	}
 
 	if err := cfg.validate(); err != nil {
@@ -150,6 +258,94 @@ func (c *Config) WithCache(enabled bool) *Config {
 	return c
 }
 
+// WithBaseURL establece la URL base del sitio consultado por el scraper.
+// Retorna la Config para encadenamiento de métodos según el patrón Builder.
+func (c *Config) WithBaseURL(baseURL string) *Config {
+	c.BaseURL = baseURL
+	return c
+}
+
+// WithMirrors establece las URLs base alternativas del sitio, en orden de preferencia.
+// Retorna la Config para encadenamiento de métodos según el patrón Builder.
+func (c *Config) WithMirrors(mirrors ...string) *Config {
+	c.Mirrors = mirrors
+	return c
+}
+
+// WithMirrorCooldown establece el tiempo durante el que se omite un host tras fallar (por defecto 5 minutos).
+// Retorna la Config para encadenamiento de métodos según el patrón Builder.
+func (c *Config) WithMirrorCooldown(cooldown time.Duration) *Config {
+	c.MirrorCooldown = cooldown
+	return c
+}
+
+// WithRetry establece la política de reintentos del scraper: el número máximo de intentos
+// por petición (1 desactiva los reintentos), la espera antes del primer reintento y la espera
+// máxima entre intentos (por defecto 3, 500ms y 10s).
+// Retorna la Config para encadenamiento de métodos según el patrón Builder.
+func (c *Config) WithRetry(maxAttempts int, baseDelay, maxDelay time.Duration) *Config {
+	c.MaxAttempts = maxAttempts
+	c.RetryBaseDelay = baseDelay
+	c.RetryMaxDelay = maxDelay
+	return c
+}
+
+// WithCircuitBreaker establece los fallos consecutivos que abren el circuito (0 lo desactiva)
+// y el tiempo que permanece abierto antes de probar de nuevo (por defecto 5 y 30s).
+// Retorna la Config para encadenamiento de métodos según el patrón Builder.
+func (c *Config) WithCircuitBreaker(threshold int, cooldown time.Duration) *Config {
+	c.BreakerThreshold = threshold
+	c.BreakerCooldown = cooldown
+	return c
+}
+
+// WithGlobalRateLimit establece el límite compartido por todas las peticiones al sitio, que se
+// aplica además del límite de cada clase (por defecto 3/s con ráfagas de 5).
+// Retorna la Config para encadenamiento de métodos según el patrón Builder.
+func (c *Config) WithGlobalRateLimit(limit RateLimit) *Config {
+	c.GlobalRateLimit = limit
+	return c
+}
+
+// WithRateLimits establece el límite de peticiones de cada clase de operación: búsquedas y
+// catálogo, fichas de anime, enlaces de episodios y página principal (por defecto 3/s con ráfagas de 5).
+// Estos límites se aplican dentro del límite global, que no se supera aunque su suma sea mayor.
+// Retorna la Config para encadenamiento de métodos según el patrón Builder.
+func (c *Config) WithRateLimits(browse, info, episode, home RateLimit) *Config {
+	c.BrowseRateLimit = browse
+	c.InfoRateLimit = info
+	c.EpisodeRateLimit = episode
+	c.HomeRateLimit = home
+	return c
+}
+
+// WithRateRecoveryInterval establece cada cuánto se recupera un 10% del límite configurado
+// tras un 429 (por defecto 10s). Un intervalo de 0 desactiva el ajuste automático.
+// Retorna la Config para encadenamiento de métodos según el patrón Builder.
+func (c *Config) WithRateRecoveryInterval(interval time.Duration) *Config {
+	c.RateRecoveryInterval = interval
+	return c
+}
+
+// Hosts retorna las URLs base del sitio en orden de preferencia: la URL base seguida
+// de los mirrors, sin duplicados ni barras finales. Si no hay URL base configurada
+// se usa DefaultBaseURL.
+func (c *Config) Hosts() []string {
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	hosts := make([]string, 0, len(c.Mirrors)+1)
+	for _, host := range append([]string{baseURL}, c.Mirrors...) {
+		host = strings.TrimRight(strings.TrimSpace(host), "/")
+		if host != "" && !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
 // InitConfig inicializa el singleton de configuración. Solo se puede ejecutar una vez.
 // Las siguientes llamadas son ignoradas si la instancia ya fue inicializada.
 // Retorna error si la configuración no valida o si el singleton ya fue inicializado con diferente Config.
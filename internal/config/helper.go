@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -54,6 +56,49 @@ func getEnvAsBool(name string, defaultVal bool) bool {
 	return defaultVal
 }
 
+// getEnvAsDuration obtiene el valor de una variable de entorno como duración (ej: "5m", "30s")
+// con un valor por defecto. Si la variable no existe o no es una duración válida, retorna defaultVal.
+func getEnvAsDuration(name string, defaultVal time.Duration) time.Duration {
+	if value := os.Getenv(name); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+
+	return defaultVal
+}
+
+// getEnvAsList obtiene el valor de una variable de entorno como lista separada por comas.
+// Descarta los elementos vacíos; si la variable no existe, retorna defaultVal.
+func getEnvAsList(name string, defaultVal []string) []string {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultVal
+	}
+
+	var list []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvAsRateLimit obtiene el límite de peticiones global o de una clase de operación desde las
+// variables SCRAPER_RATE_{class} (peticiones por segundo) y SCRAPER_BURST_{class} (ráfaga).
+// Cada valor ausente o inválido se reemplaza por el de defaultVal.
+func getEnvAsRateLimit(class string, defaultVal RateLimit) RateLimit {
+	limit := defaultVal
+	if value := os.Getenv("SCRAPER_RATE_" + class); value != "" {
+		if rate, err := strconv.ParseFloat(value, 64); err == nil {
+			limit.Rate = rate
+		}
+	}
+	limit.Burst = getEnvAsInt("SCRAPER_BURST_"+class, defaultVal.Burst)
+	return limit
+}
+
 // findProjectRoot busca el directorio raíz del proyecto Go recorriendo hacia arriba en la estructura de directorios
 // hasta encontrar un archivo go.mod. Comienza desde el directorio de trabajo actual y sube recursivamente
 // hacia los directorios padres hasta encontrar el archivo go.mod o llegar a la raíz del sistema de archivos.
@@ -1,7 +1,16 @@
 // Package config contiene funciones de validación para la estructura de configuración.
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+)
+
+// Validate verifica que la configuración sea válida antes de usarla para construir servicios.
+// Retorna el mismo error descriptivo que se produce al cargar la configuración desde el entorno.
+func (c *Config) Validate() error {
+	return c.validate()
+}
 
 // validate verifica que todos los parámetros de configuración sean válidos y cumplan con los requerimientos.
 // Valida:
@@ -9,6 +18,11 @@ import "fmt"
 // - CACHE_PORT: debe estar en el rango válido de puertos (1-65535)
 // - CACHE_TTL: debe ser un número no negativo (en minutos)
 // - LOG_ENV: debe ser uno de los valores permitidos (development, staging, production)
+// - SCRAPER_BASE_URL y SCRAPER_MIRRORS: si se definen, deben ser URLs http(s) absolutas
+// - SCRAPER_MIRROR_COOLDOWN, SCRAPER_RETRY_BASE_DELAY y SCRAPER_RETRY_MAX_DELAY: no pueden ser negativos
+// - SCRAPER_MAX_ATTEMPTS y SCRAPER_BREAKER_THRESHOLD: no pueden ser negativos
+// - SCRAPER_BREAKER_COOLDOWN y SCRAPER_RATE_RECOVERY_INTERVAL: no pueden ser negativos
+// - SCRAPER_RATE_* y SCRAPER_BURST_*: no pueden ser negativos para ninguna clase de operación
 // Retorna un error descriptivo si alguna validación falla, o nil si todas las validaciones pasan.
 func (c *Config) validate() error {
 	if c.AppName == "" {
@@ -28,5 +42,61 @@ func (c *Config) validate() error {
 		return fmt.Errorf("invalid LOG_ENV: must be development, staging or production, got %s", c.LogEnv)
 	}
 
+	if c.BaseURL != "" && !validHostURL(c.BaseURL) {
+		return fmt.Errorf("invalid SCRAPER_BASE_URL: must be an absolute http(s) URL, got %q", c.BaseURL)
+	}
+
+	for _, mirror := range c.Mirrors {
+		if !validHostURL(mirror) {
+			return fmt.Errorf("invalid SCRAPER_MIRRORS: must be absolute http(s) URLs, got %q", mirror)
+		}
+	}
+
+	if c.MirrorCooldown < 0 {
+		return fmt.Errorf("SCRAPER_MIRROR_COOLDOWN must be positive, got %s", c.MirrorCooldown)
+	}
+
+	if c.MaxAttempts < 0 {
+		return fmt.Errorf("SCRAPER_MAX_ATTEMPTS must be positive, got %d", c.MaxAttempts)
+	}
+
+	if c.RetryBaseDelay < 0 || c.RetryMaxDelay < 0 {
+		return fmt.Errorf("SCRAPER_RETRY_BASE_DELAY and SCRAPER_RETRY_MAX_DELAY must be positive, got %s and %s", c.RetryBaseDelay, c.RetryMaxDelay)
+	}
+
+	if c.BreakerThreshold < 0 {
+		return fmt.Errorf("SCRAPER_BREAKER_THRESHOLD must be positive, got %d", c.BreakerThreshold)
+	}
+
+	if c.BreakerCooldown < 0 {
+		return fmt.Errorf("SCRAPER_BREAKER_COOLDOWN must be positive, got %s", c.BreakerCooldown)
+	}
+
+	rateLimits := []struct {
+		class string
+		limit RateLimit
+	}{
+		{"GLOBAL", c.GlobalRateLimit},
+		{"BROWSE", c.BrowseRateLimit},
+		{"INFO", c.InfoRateLimit},
+		{"EPISODE", c.EpisodeRateLimit},
+		{"HOME", c.HomeRateLimit},
+	}
+	for _, rl := range rateLimits {
+		if rl.limit.Rate < 0 || rl.limit.Burst < 0 {
+			return fmt.Errorf("SCRAPER_RATE_%[1]s and SCRAPER_BURST_%[1]s must be positive, got %[2]v and %[3]d", rl.class, rl.limit.Rate, rl.limit.Burst)
+		}
+	}
+
+	if c.RateRecoveryInterval < 0 {
+		return fmt.Errorf("SCRAPER_RATE_RECOVERY_INTERVAL must be positive, got %s", c.RateRecoveryInterval)
+	}
+
 	return nil
 }
+
+// validHostURL reporta si value es una URL http(s) absoluta con host.
+func validHostURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
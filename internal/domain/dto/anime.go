@@ -3,7 +3,8 @@
 //
 // anime.go define la estructura básica de respuesta de anime (AnimeResponse) que incluye
 // información fundamental como ID, título, sinopsis, tipo, puntuación e imagen.
-// También define los tipos de categoría de anime disponibles (Anime, OVA, Película, Especial).
+// También define los tipos de categoría de anime disponibles (Anime, OVA, Película, Especial)
+// y AnimeRef, la referencia mínima usada en listados como los animes en emisión.
 package dto
 
 // CategoryAnime representa el tipo de categoría de contenido de anime.
@@ -32,3 +33,11 @@ type AnimeResponse struct {
 	Animes     []AnimeStruct // Lista de animes encontrados
 	TotalPages uint          // Número total de páginas disponibles para paginación
 }
+
+// AnimeRef es una referencia mínima a un anime, usada en listados que no incluyen
+// sinopsis, puntuación ni imagen (ej: la barra lateral de animes en emisión).
+type AnimeRef struct {
+	ID    string        // Identificador único del anime (ej: "one-piece-tv")
+	Title string        // Título del anime
+	Type  CategoryAnime // Tipo/Categoría del anime
+}
@@ -6,7 +6,8 @@
 // - Géneros del anime
 // - Estado de emisión (En Emisión o Finalizado)
 // - Información del próximo episodio
-// - Lista completa de episodios disponibles
+// - Lista completa de episodios disponibles con su ID, enlace y miniatura
+// - Títulos alternativos, votos, seguidores e imagen de portada (banner)
 package dto
 
 // StatusAnime representa el estado de emisión del anime.
@@ -24,12 +25,50 @@ type AnimeInfoResponse struct {
 	Genres       []string       // Géneros del anime (Acción, Aventura, Romance, etc.)
 	Status       StatusAnime    // Estado actual de emisión del anime
 	NextEpisode  string         // Fecha del próximo episodio a emitirse
-	Episodes     []int          // Lista de números de episodios disponibles
+	Episodes     []Episode      // Episodios disponibles con su ID, enlace y miniatura
+	AltTitles    []string       // Títulos alternativos (romanizados, en inglés o japonés)
+	Votes        int            // Número de votos de la calificación
+	Followers    int            // Número de seguidores del anime en el sitio
+	Banner       string         // URL de la imagen de portada (banner) de la ficha
+}
+
+// EpisodeNumbers retorna los números de los episodios disponibles, en el mismo orden que Episodes.
+// Se mantiene por compatibilidad con el antiguo campo Episodes []int.
+func (a AnimeInfoResponse) EpisodeNumbers() []int {
+	numbers := make([]int, len(a.Episodes))
+	for i, episode := range a.Episodes {
+		numbers[i] = episode.Number
+	}
+	return numbers
+}
+
+// Episode contiene la información de un episodio disponible de un anime.
+type Episode struct {
+	Number       int    // Número del episodio
+	ID           int    // Identificador numérico del episodio en el sitio
+	URL          string // Ruta de la página del episodio (ej: "/ver/one-piece-tv-1090")
+	ThumbnailURL string // URL de la miniatura del episodio en el CDN; vacía si se desconoce el ID del anime
 }
 
 // AnimeRelated contiene información básica de animes relacionados.
 type AnimeRelated struct {
-	ID       string // Identificador único del anime relacionado
-	Title    string // Título del anime relacionado
-	Category string // Tipo de relación (Secuela, Precuela, Spin-off, etc.)
+	ID       string       // Identificador único del anime relacionado
+	Title    string       // Título del anime relacionado
+	Category string       // Tipo de relación tal como aparece en el sitio (Secuela, Precuela, Spin-off, etc.)
+	Type     RelationType // Tipo de relación clasificado a partir de Category
 }
+
+// RelationType clasifica la relación entre un anime y un anime relacionado.
+// Se lee desde el anime que lista la relación: RelationSequel indica que el
+// anime relacionado es la secuela del anime consultado.
+type RelationType string
+
+const (
+	RelationSequel      RelationType = "sequel"      // Secuela
+	RelationPrequel     RelationType = "prequel"     // Precuela
+	RelationSideStory   RelationType = "side-story"  // Historia paralela (películas, especiales, OVAs)
+	RelationParentStory RelationType = "parent"      // Historia principal de una historia paralela
+	RelationAlternative RelationType = "alternative" // Versión alternativa de la misma historia
+	RelationSpinOff     RelationType = "spin-off"    // Spin-off
+	RelationOther       RelationType = "other"       // Relación no reconocida
+)
@@ -0,0 +1,9 @@
+// Package dto - batch.go
+// Este archivo define las opciones de las operaciones por lotes, que consultan
+// varios recursos de forma concurrente compartiendo el rate limiter del scraper.
+package dto
+
+// BatchOptions configura una operación por lotes.
+type BatchOptions struct {
+	Concurrency int // Número máximo de consultas simultáneas (por defecto 4)
+}
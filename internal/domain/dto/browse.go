@@ -0,0 +1,45 @@
+// Package dto - browse.go
+// Este archivo define el filtro del catálogo (/browse) del sitio. Permite combinar
+// géneros, años, tipos y estados de emisión, y elegir el orden de los resultados.
+// Los valores corresponden a los aceptados por el formulario de filtros del sitio.
+package dto
+
+// BrowseType representa el tipo de contenido aceptado por el filtro del catálogo.
+type BrowseType string
+
+const (
+	BrowseTV      BrowseType = "tv"      // Serie de anime
+	BrowseMovie   BrowseType = "movie"   // Película
+	BrowseSpecial BrowseType = "special" // Especial
+	BrowseOVA     BrowseType = "ova"     // Original Video Animation
+)
+
+// BrowseStatus representa el estado de emisión aceptado por el filtro del catálogo.
+type BrowseStatus int
+
+const (
+	BrowseEmision      BrowseStatus = 1 // En emisión
+	BrowseFinalizado   BrowseStatus = 2 // Finalizado
+	BrowseProximamente BrowseStatus = 3 // Próximamente
+)
+
+// BrowseOrder representa el criterio de ordenamiento del catálogo.
+type BrowseOrder string
+
+const (
+	OrderDefault BrowseOrder = "default" // Orden por defecto del sitio
+	OrderUpdated BrowseOrder = "updated" // Recientemente actualizados
+	OrderAdded   BrowseOrder = "added"   // Recientemente agregados
+	OrderTitle   BrowseOrder = "title"   // Nombre A-Z
+	OrderRating  BrowseOrder = "rating"  // Calificación
+)
+
+// BrowseFilter contiene los filtros del catálogo. Los campos vacíos no filtran.
+// Dentro de cada campo los valores se combinan con O; entre campos, con Y.
+type BrowseFilter struct {
+	Genres []string       // Slugs de género (ej: "accion", "comedia")
+	Years  []int          // Años de emisión (ej: 2024)
+	Types  []BrowseType   // Tipos de contenido
+	Status []BrowseStatus // Estados de emisión
+	Order  BrowseOrder    // Criterio de ordenamiento (vacío equivale a OrderDefault)
+}
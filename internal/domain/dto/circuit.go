@@ -0,0 +1,14 @@
+// Package dto - circuit.go
+// Este archivo define los estados del circuit breaker que protege al sitio consultado
+// por el scraper: cerrado (peticiones normales), abierto (peticiones rechazadas) y
+// semiabierto (una petición de prueba decide si el circuito vuelve a cerrarse).
+package dto
+
+// CircuitState es el estado del circuit breaker del scraper.
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // El sitio responde; las peticiones pasan con normalidad
+	CircuitOpen     CircuitState = "open"      // El sitio falló repetidamente; las peticiones se rechazan
+	CircuitHalfOpen CircuitState = "half-open" // Terminó la espera; una petición de prueba está en curso o pendiente
+)
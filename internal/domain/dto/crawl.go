@@ -0,0 +1,49 @@
+// Package dto - crawl.go
+// Este archivo define las estructuras del rastreo completo del catálogo.
+// CrawlCheckpoint es el progreso persistido que permite reanudar un rastreo tras
+// una caída, CrawlProgress es el evento que recibe el consumidor y CrawlOptions
+// configura cómo se entregan los resultados.
+package dto
+
+import "time"
+
+// CrawlPhase identifica la fase en la que se encuentra un rastreo del catálogo.
+type CrawlPhase string
+
+const (
+	CrawlBrowse CrawlPhase = "browse" // Recorriendo las páginas de /browse para descubrir IDs
+	CrawlInfo   CrawlPhase = "info"   // Consultando AnimeInfo de cada ID descubierto
+	CrawlDone   CrawlPhase = "done"   // Rastreo completado
+)
+
+// CrawlCheckpoint contiene el progreso de un rastreo; se guarda tras cada paso.
+type CrawlCheckpoint struct {
+	Phase      CrawlPhase // Fase actual del rastreo
+	NextPage   uint       // Próxima página de /browse por recorrer
+	TotalPages uint       // Total de páginas del catálogo
+	IDs        []string   // IDs descubiertos en la fase de recorrido, en orden
+	NextIndex  int        // Índice en IDs del próximo anime por consultar
+	Failed     []string   // IDs cuya información no pudo obtenerse
+	UpdatedAt  time.Time  // Momento del último guardado
+}
+
+// CrawlProgress describe un paso completado del rastreo.
+type CrawlProgress struct {
+	Phase      CrawlPhase // Fase del paso
+	Page       uint       // Página recorrida (fase browse)
+	TotalPages uint       // Total de páginas del catálogo
+	AnimeID    string     // Anime consultado (fase info)
+	Done       int        // Animes consultados hasta ahora
+	Total      int        // Total de animes descubiertos
+	Err        error      // Error del paso, si el anime no pudo consultarse
+}
+
+// CrawlOptions configura un rastreo del catálogo.
+type CrawlOptions struct {
+	// OnAnime recibe la información de cada anime. Si retorna error, el rastreo se detiene
+	// sin avanzar el checkpoint, de modo que ese anime se vuelve a entregar al reanudar.
+	OnAnime func(AnimeInfoResponse) error
+
+	// Progress, si no es nil, se invoca tras cada página recorrida y cada anime consultado.
+	Progress func(CrawlProgress)
+}
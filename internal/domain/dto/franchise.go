@@ -0,0 +1,30 @@
+// Package dto - franchise.go
+// Este archivo define las estructuras de una franquicia: el conjunto de animes
+// alcanzables desde un anime siguiendo sus animes relacionados, junto con las
+// relaciones entre ellos y un orden de visualización sugerido.
+package dto
+
+// Franchise contiene los animes de una franquicia en orden de visualización sugerido.
+type Franchise struct {
+	Root       string              // ID del anime desde el que se resolvió la franquicia
+	WatchOrder []FranchiseEntry    // Animes de la franquicia en orden cronológico sugerido
+	Relations  []FranchiseRelation // Relaciones encontradas entre los animes de la franquicia
+	Unresolved []string            // IDs cuya información no pudo obtenerse; aparecen en WatchOrder solo con ID y título
+	Truncated  bool                // true si se alcanzó el límite de profundidad con relaciones sin explorar
+}
+
+// FranchiseEntry representa un anime dentro de una franquicia.
+type FranchiseEntry struct {
+	Anime    AnimeStruct  // Información básica del anime
+	Depth    int          // Número de saltos de relación desde el anime de partida
+	Relation RelationType // Relación por la que se descubrió el anime; vacía para el anime de partida
+}
+
+// FranchiseRelation representa una relación entre dos animes de una franquicia,
+// leída desde el anime From: Type indica qué es To respecto de From.
+type FranchiseRelation struct {
+	From     string       // ID del anime que lista la relación
+	To       string       // ID del anime relacionado
+	Type     RelationType // Tipo de relación clasificado
+	Category string       // Tipo de relación tal como aparece en el sitio
+}
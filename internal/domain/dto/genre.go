@@ -0,0 +1,11 @@
+// Package dto - genre.go
+// Este archivo define la estructura Genre que representa un género del catálogo.
+// Relaciona el slug usado por los filtros de /browse con el nombre visible en el sitio,
+// el mismo que aparece en AnimeInfoResponse.Genres.
+package dto
+
+// Genre contiene un género disponible en el filtro del catálogo.
+type Genre struct {
+	Slug string // Identificador usado en BrowseFilter.Genres (ej: "artes-marciales")
+	Name string // Nombre visible del género (ej: "Artes Marciales")
+}
@@ -0,0 +1,12 @@
+// Package dto - home.go
+// Este archivo define HomeSnapshot, la instantánea de la página de inicio del sitio.
+// Agrupa las secciones que se obtienen de una sola petición: animes recientes,
+// episodios recientes y animes en emisión.
+package dto
+
+// HomeSnapshot contiene todas las secciones de la página de inicio obtenidas en una sola petición.
+type HomeSnapshot struct {
+	RecentAnime    []AnimeStruct         // Animes recientemente agregados
+	RecentEpisodes []EpisodeListResponse // Episodios recientemente publicados
+	OnAir          []AnimeRef            // Animes actualmente en emisión
+}
@@ -2,20 +2,43 @@
 // Este archivo define las estructuras para manejar enlaces de reproducción de episodios.
 // LinkResponse contiene información de un episodio específico junto con todos sus
 // enlaces de reproducción disponibles. LinkSource representa cada servidor de video
-// individual con su URL y código de reproducción.
+// individual con su URL, código de reproducción e idioma. DownloadSource representa
+// cada fila de la tabla de descargas de la página del episodio.
 package dto
 
+// Language identifica el idioma de un grupo de servidores de video del sitio.
+type Language string
+
+const (
+	LanguageSUB Language = "SUB" // Audio original con subtítulos en español
+	LanguageLAT Language = "LAT" // Doblaje latinoamericano
+)
+
 // LinkResponse contiene información de un episodio con sus enlaces de reproducción disponibles.
 type LinkResponse struct {
-	ID      string       // Identificador único del anime
-	Title   string       // Título del anime
-	Episode uint         // Número del episodio
-	Link    []LinkSource // Lista de enlaces de reproducción disponibles para este episodio
+	ID          string           // Identificador único del anime
+	Title       string           // Título del anime
+	Episode     uint             // Número del episodio
+	AnimeID     int              // Identificador numérico del anime en el sitio (var anime_id)
+	EpisodeID   int              // Identificador numérico del episodio en el sitio (var episode_id)
+	PrevEpisode uint             // Número del episodio anterior; 0 si no existe
+	NextEpisode uint             // Número del episodio siguiente; 0 si no existe
+	Link        []LinkSource     // Lista de enlaces de reproducción disponibles para este episodio
+	Downloads   []DownloadSource // Enlaces de descarga directa de la tabla de descargas
 }
 
 // LinkSource representa un servidor de video individual para reproducción.
 type LinkSource struct {
-	Server string // Nombre del servidor de video (ej: "Zippyshare", "Mega", "Google Drive")
-	URL    string // URL del enlace de reproducción/descarga
-	Code   string // Código de embed o identificador del video en el servidor
+	Server   string   // Nombre del servidor de video (ej: "Zippyshare", "Mega", "Google Drive")
+	URL      string   // URL del enlace de reproducción/descarga
+	Code     string   // Código de embed o identificador del video en el servidor
+	Language Language // Idioma del video (ej: LanguageSUB, LanguageLAT)
+}
+
+// DownloadSource representa un enlace de descarga directa de un episodio.
+type DownloadSource struct {
+	Server   string   // Nombre del servidor de descarga (ej: "MEGA", "Stape")
+	Format   string   // Formato del archivo (ej: "MP4")
+	Language Language // Idioma del archivo (ej: LanguageSUB, LanguageLAT)
+	URL      string   // URL de descarga
 }
@@ -0,0 +1,24 @@
+// Package dto - ratelimit.go
+// Este archivo define las clases de operación del scraper, cada una con su propio límite de
+// peticiones al sitio, y el estado de ese límite expuesto para métricas.
+package dto
+
+// RequestClass es una clase de operación del scraper con su propio límite de peticiones.
+type RequestClass string
+
+const (
+	RequestBrowse  RequestClass = "browse"  // Búsquedas, catálogo y géneros (/browse)
+	RequestInfo    RequestClass = "info"    // Fichas de anime (/anime/)
+	RequestEpisode RequestClass = "episode" // Enlaces de episodios (/ver/)
+	RequestHome    RequestClass = "home"    // Página principal (/)
+)
+
+// RateLimitState es el estado del límite de peticiones de una clase de operación.
+// Tras un 429 el ritmo efectivo baja del configurado y se recupera gradualmente.
+type RateLimitState struct {
+	Class          RequestClass
+	Rate           float64 // Ritmo efectivo actual, en peticiones por segundo
+	ConfiguredRate float64 // Ritmo configurado, en peticiones por segundo
+	Burst          int     // Peticiones que pueden realizarse de golpe
+	Throttled      bool    // El ritmo está reducido por un 429 reciente
+}
@@ -0,0 +1,28 @@
+// Package dto - schedule.go
+// Este archivo define las estructuras del calendario semanal de emisión.
+// Schedule agrupa por día de la semana los próximos episodios de los animes en emisión,
+// calculados a partir de la fecha del próximo episodio de cada serie.
+package dto
+
+import "time"
+
+// Schedule contiene el calendario de emisión de una semana, un ScheduleDay por día.
+type Schedule struct {
+	WeekStart time.Time     // Primer día de la semana (medianoche)
+	Days      []ScheduleDay // Los 7 días de la semana a partir de WeekStart
+	UpdatedAt time.Time     // Momento de la consulta más antigua entre las series del calendario
+}
+
+// ScheduleDay contiene los episodios que se emiten en un día concreto.
+type ScheduleDay struct {
+	Weekday time.Weekday    // Día de la semana
+	Date    time.Time       // Fecha del día (medianoche)
+	Entries []ScheduleEntry // Episodios que se emiten ese día
+}
+
+// ScheduleEntry representa la emisión de un episodio de un anime.
+type ScheduleEntry struct {
+	Anime   AnimeRef  // Anime que se emite
+	Episode int       // Número estimado del episodio; 0 si no se conoce
+	AirDate time.Time // Fecha de emisión del episodio
+}
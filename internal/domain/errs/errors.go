@@ -0,0 +1,116 @@
+// Package errs define la taxonomía de errores tipados de la aplicación.
+// Los adaptadores (scraper, parser, caché) y los servicios de dominio retornan estos
+// errores, envueltos cuando corresponde, para que los consumidores puedan distinguir
+// un recurso inexistente de un límite de peticiones o de un cambio en el HTML del sitio
+// usando errors.Is y errors.As en lugar de comparar mensajes.
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrNotFound indica que el recurso solicitado no existe en el sitio (HTTP 404).
+	ErrNotFound = errors.New("recurso no encontrado")
+
+	// ErrRateLimited indica que el sitio rechazó la petición por exceso de solicitudes (HTTP 429).
+	ErrRateLimited = errors.New("límite de peticiones excedido")
+
+	// ErrCacheMiss indica que la clave solicitada no existe en el caché.
+	ErrCacheMiss = errors.New("clave no encontrada en caché")
+
+	// ErrInvalidInput indica que un parámetro recibido no es válido (por ejemplo, un ID vacío).
+	ErrInvalidInput = errors.New("parámetro inválido")
+
+	// ErrClosed indica que se invocó una operación sobre un servicio que ya fue cerrado.
+	ErrClosed = errors.New("el servicio AnimeFlv está cerrado")
+
+	// ErrCircuitOpen indica que el circuit breaker del scraper está abierto tras fallos
+	// consecutivos del sitio y la petición se rechazó sin contactarlo.
+	ErrCircuitOpen = errors.New("circuito abierto: el sitio no está disponible")
+
+	// ErrChallenged indica que el sitio respondió con una página de verificación anti-bot
+	// (por ejemplo, el desafío JavaScript de Cloudflare) en lugar del contenido solicitado.
+	ErrChallenged = errors.New("el sitio respondió con una verificación anti-bot")
+)
+
+// Páginas del sitio reportadas en ParseError.Page.
+const (
+	PageBrowse    = "browse"  // Listado y búsqueda de animes (/browse)
+	PageAnimeInfo = "anime"   // Ficha de información de un anime (/anime/{id})
+	PageEpisode   = "episode" // Página de reproducción de un episodio (/ver/{id}-{n})
+	PageHome      = "home"    // Página principal del sitio
+)
+
+// UpstreamStatusError indica que el sitio respondió con un código de estado HTTP inesperado.
+// Satisface errors.Is con ErrNotFound para 404 y con ErrRateLimited para 429.
+type UpstreamStatusError struct {
+	Code       int           // Código de estado HTTP recibido
+	URL        string        // URL solicitada
+	RetryAfter time.Duration // Espera indicada por la cabecera Retry-After; 0 si no se indicó
+}
+
+// Error describe el código de estado y la URL que lo produjo.
+func (e *UpstreamStatusError) Error() string {
+	return fmt.Sprintf("código de estado HTTP inesperado %d en %s", e.Code, e.URL)
+}
+
+// Is permite comparar el error con los sentinelas equivalentes a su código de estado.
+func (e *UpstreamStatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.Code == http.StatusNotFound
+	case ErrRateLimited:
+		return e.Code == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// ParseError indica que el HTML recibido no contiene la información esperada,
+// normalmente porque el sitio cambió su estructura.
+type ParseError struct {
+	Page  string // Página que se estaba parseando (ver constantes Page*)
+	Field string // Campo o sección que no se pudo extraer
+	Err   error  // Error subyacente, si existe
+}
+
+// Error describe la página y el campo que no se pudieron parsear.
+func (e *ParseError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("error al parsear %q en la página %s: %v", e.Field, e.Page, e.Err)
+	}
+	return fmt.Sprintf("error al parsear %q en la página %s", e.Field, e.Page)
+}
+
+// Unwrap retorna el error subyacente.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ChallengeError indica que el sitio sirvió una página de verificación anti-bot en lugar del
+// contenido solicitado. Satisface errors.Is con ErrChallenged y permite distinguir un bloqueo
+// de un cambio en el HTML del sitio (ParseError).
+type ChallengeError struct {
+	URL      string // URL solicitada
+	Code     int    // Código de estado HTTP recibido (normalmente 200, 403 o 503)
+	Provider string // Proveedor de la verificación (ej: "cloudflare", "ddos-guard")
+	Marker   string // Indicio que identificó la página de verificación
+	RayID    string // Identificador de la petición en Cloudflare (cabecera CF-Ray), si existe
+}
+
+// Error describe el proveedor, el indicio detectado y la URL bloqueada.
+func (e *ChallengeError) Error() string {
+	msg := fmt.Sprintf("verificación anti-bot de %s en %s (HTTP %d, indicio %q)", e.Provider, e.URL, e.Code, e.Marker)
+	if e.RayID != "" {
+		msg += ", CF-Ray " + e.RayID
+	}
+	return msg
+}
+
+// Is permite comparar el error con ErrChallenged.
+func (e *ChallengeError) Is(target error) bool {
+	return target == ErrChallenged
+}
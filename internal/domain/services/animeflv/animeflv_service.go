@@ -8,98 +8,314 @@ package animeflv
 import (
 	"context"
 	"fmt"
+	"iter"
+	"slices"
 
 	"github.com/dst3v3n/api-anime/internal/adapters/cache"
 	"github.com/dst3v3n/api-anime/internal/adapters/scrapers/animeflv"
 	"github.com/dst3v3n/api-anime/internal/config"
 	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/services"
 	"github.com/dst3v3n/api-anime/internal/ports"
-	"github.com/valkey-io/valkey-go"
+	"github.com/rs/zerolog"
 )
 
 // AnimeflvService es el servicio principal que coordina las operaciones de AnimeFlv.
 // Delega las responsabilidades a servicios especializados para búsqueda, contenido
 // reciente y detalles de anime, siguiendo el principio de responsabilidad única.
-// Integra caché distribuido (Valkey) en todos los sub-servicios para optimizar rendimiento.
+// Todos los sub-servicios comparten el scraper envuelto con la cadena de middlewares,
+// que integra caché distribuido (Valkey) y logging para cada operación.
 type AnimeflvService struct {
-	scraper ports.ScraperPort
-	search  searchService
-	recent  recentService
-	detail  detailService
+	scraper   ports.ScraperPort
+	circuit   ports.CircuitStatePort
+	limits    ports.RateLimitPort
+	logger    zerolog.Logger
+	lifecycle *lifecycle
+	search    searchService
+	recent    recentService
+	detail    detailService
+	schedule  *scheduleIndex
+}
+
+// Dependencies agrupa las dependencias que el servicio AnimeFlv necesita para operar.
+// Permite construir el servicio sin leer el singleton global de configuración.
+type Dependencies struct {
+	Scraper ports.ScraperPort // Scraper que obtiene los datos del sitio
+	Cache   ports.CachePort   // Caché distribuido; obligatorio solo si el caché está habilitado
+	Config  *config.Config    // Configuración de la aplicación
+	Logger  zerolog.Logger    // Logger estructurado del servicio
+	OnClose []func()          // Liberan los recursos propios del servicio al cerrarlo
+
+	// Middlewares decoran el scraper entre los servicios y los middlewares integrados
+	// (caché y logging). El primero es el más externo.
+	Middlewares []ports.ScraperMiddleware
 }
 
 // NewAnimeflvService crea una nueva instancia del servicio AnimeFlv.
 // Inicializa el scraper, conexión a Valkey para caché distribuido,
 // y todos los sub-servicios necesarios para las operaciones.
+// Lee el singleton global de configuración y entra en pánico si no puede conectar con Valkey;
+// usa NewAnimeflvServiceWith para recibir un error en su lugar.
 func NewAnimeflvService() *AnimeflvService {
-	scraper := animeflv.NewClient()
-
 	logger := config.GetLogger()
-	config, err := config.GetConfig()
+	cfg, err := config.GetConfig()
 	if err != nil {
 		logger.Error().Err(err).Msg("Error al obtener la configuración de Valkey")
 		return nil
 	}
 
-	initAddress := fmt.Sprintf("redis://%s:%d/%d", config.CacheHost, config.CachePort, config.CacheDB)
+	var cachePort ports.CachePort
+	var onClose []func()
+	if cfg.EnableCache {
+		client, err := cache.NewValkeyClient(cfg)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error al conectar con Valkey")
+			panic(err)
+		}
+		cachePort = cache.NewValkeyCacheWithConfig(client, cfg)
+		onClose = append(onClose, client.Close)
+	}
 
-	client, err := valkey.NewClient(valkey.MustParseURL(initAddress))
+	service, err := NewAnimeflvServiceWith(Dependencies{
+		Scraper: animeflv.NewClientWithConfig(nil, cfg),
+		Cache:   cachePort,
+		Config:  cfg,
+		Logger:  logger,
+		OnClose: onClose,
+	})
 	if err != nil {
-		logger.Error().Err(err).Msg("Error al conectar con Valkey")
+		logger.Error().Err(err).Msg("Error al inicializar el servicio AnimeFlv")
 		panic(err)
 	}
-	return &AnimeflvService{
-		scraper: scraper,
-		search: searchService{
-			scraper:     scraper,
-			cache:       cache.NewValkeyCache(client),
-			enableCache: config.EnableCache,
-		},
-		recent: recentService{
-			scraper:     scraper,
-			cache:       cache.NewValkeyCache(client),
-			enableCache: config.EnableCache,
-		},
-		detail: detailService{
-			scraper:     scraper,
-			cache:       cache.NewValkeyCache(client),
-			enableCache: config.EnableCache,
-		},
+	return service
+}
+
+// NewAnimeflvServiceWith crea el servicio AnimeFlv a partir de dependencias ya construidas.
+// Retorna error si falta el scraper o la configuración, o si el caché está habilitado sin un CachePort.
+// Los recursos de deps.OnClose pasan a pertenecer al servicio: si la construcción falla se liberan
+// antes de retornar el error.
+func NewAnimeflvServiceWith(deps Dependencies) (*AnimeflvService, error) {
+	if err := validateDependencies(deps); err != nil {
+		for _, release := range deps.OnClose {
+			release()
+		}
+		return nil, err
 	}
+
+	enableCache := deps.Config.EnableCache
+	deps.Logger.Debug().Bool("cache", enableCache).Msg("Servicio AnimeFlv inicializado")
+
+	// Cadena: servicios -> middlewares del consumidor -> caché -> logging -> scraper
+	middlewares := append(slices.Clone(deps.Middlewares),
+		services.CachingMiddleware(deps.Cache, enableCache),
+		services.LoggingMiddleware(deps.Logger),
+	)
+	scraper := services.Chain(deps.Scraper, middlewares...)
+
+	circuit, _ := deps.Scraper.(ports.CircuitStatePort)
+	limits, _ := deps.Scraper.(ports.RateLimitPort)
+
+	return &AnimeflvService{
+		scraper:   scraper,
+		circuit:   circuit,
+		limits:    limits,
+		logger:    deps.Logger,
+		lifecycle: newLifecycle(deps.OnClose),
+		search:    searchService{scraper: scraper},
+		recent:    recentService{scraper: scraper},
+		detail:    detailService{scraper: scraper},
+		schedule:  newScheduleIndex(),
+	}, nil
 }
 
 // SearchAnime busca animes por nombre con paginación.
 // Delega la operación al servicio de búsqueda especializado.
 func (afs *AnimeflvService) SearchAnime(ctx context.Context, anime string, page uint) (dto.AnimeResponse, error) {
+	ctx, done, err := afs.lifecycle.begin(ctx)
+	if err != nil {
+		return dto.AnimeResponse{}, err
+	}
+	defer done()
+
 	return afs.search.SearchAnime(ctx, anime, page)
 }
 
+// SearchIter recorre todos los resultados de una búsqueda, página por página.
+// Cada página se solicita de forma perezosa a través de SearchAnime, por lo que respeta
+// el rate limiter y reutiliza las páginas cacheadas. La iteración termina al llegar a la
+// última página, cuando el consumidor deja de iterar o tras entregar el primer error.
+func (afs *AnimeflvService) SearchIter(ctx context.Context, query string) iter.Seq2[dto.AnimeStruct, error] {
+	return func(yield func(dto.AnimeStruct, error) bool) {
+		for page := uint(1); ; page++ {
+			result, err := afs.SearchAnime(ctx, query, page)
+			if err != nil {
+				yield(dto.AnimeStruct{}, err)
+				return
+			}
+
+			for _, anime := range result.Animes {
+				if !yield(anime, nil) {
+					return
+				}
+			}
+
+			if page >= result.TotalPages {
+				return
+			}
+		}
+	}
+}
+
 // Search obtiene todos los animes disponibles sin filtros de búsqueda.
-// Delega la operación al servicio de búsqueda especializado con caché integrado.
+// Delega la operación al servicio de búsqueda; el middleware de caché guarda el listado.
 func (afs *AnimeflvService) Search(ctx context.Context) (dto.AnimeResponse, error) {
+	ctx, done, err := afs.lifecycle.begin(ctx)
+	if err != nil {
+		return dto.AnimeResponse{}, err
+	}
+	defer done()
+
 	return afs.search.Search(ctx)
 }
 
+// Browse obtiene una página del catálogo aplicando filtros de género, año, tipo, estado y orden.
+// Delega la operación al servicio de búsqueda; cada combinación de filtros se cachea por separado.
+func (afs *AnimeflvService) Browse(ctx context.Context, filter dto.BrowseFilter, page uint) (dto.AnimeResponse, error) {
+	ctx, done, err := afs.lifecycle.begin(ctx)
+	if err != nil {
+		return dto.AnimeResponse{}, err
+	}
+	defer done()
+
+	return afs.search.Browse(ctx, filter, page)
+}
+
+// Genres obtiene el catálogo de géneros con su slug para Browse y su nombre visible.
+// El resultado se cachea durante 24 horas.
+func (afs *AnimeflvService) Genres(ctx context.Context) ([]dto.Genre, error) {
+	ctx, done, err := afs.lifecycle.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	return afs.search.Genres(ctx)
+}
+
 // AnimeInfo obtiene información detallada de un anime específico por su ID.
 // Delega la operación al servicio de detalles.
 func (afs *AnimeflvService) AnimeInfo(ctx context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+	ctx, done, err := afs.lifecycle.begin(ctx)
+	if err != nil {
+		return dto.AnimeInfoResponse{}, err
+	}
+	defer done()
+
 	return afs.detail.AnimeInfo(ctx, idAnime)
 }
 
 // Links obtiene los enlaces de reproducción para un episodio específico.
+// Si se indican idiomas, solo retorna los enlaces de esos idiomas.
 // Delega la operación al servicio de detalles.
-func (afs *AnimeflvService) Links(ctx context.Context, idAnime string, episode uint) (dto.LinkResponse, error) {
-	return afs.detail.Links(ctx, idAnime, episode)
+func (afs *AnimeflvService) Links(ctx context.Context, idAnime string, episode uint, languages ...dto.Language) (dto.LinkResponse, error) {
+	ctx, done, err := afs.lifecycle.begin(ctx)
+	if err != nil {
+		return dto.LinkResponse{}, err
+	}
+	defer done()
+
+	return afs.detail.Links(ctx, idAnime, episode, languages...)
 }
 
 // RecentAnime obtiene la lista de animes recientemente agregados.
-// Delega la operación al servicio de contenido reciente.
+// Delega la operación al servicio de contenido reciente, que la toma de la instantánea de la página de inicio.
 func (afs *AnimeflvService) RecentAnime(ctx context.Context) ([]dto.AnimeStruct, error) {
+	ctx, done, err := afs.lifecycle.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
 	return afs.recent.RecentAnime(ctx)
 }
 
 // RecentEpisode obtiene la lista de episodios recientemente publicados.
-// Delega la operación al servicio de contenido reciente.
+// Delega la operación al servicio de contenido reciente, que la toma de la instantánea de la página de inicio.
 func (afs *AnimeflvService) RecentEpisode(ctx context.Context) ([]dto.EpisodeListResponse, error) {
+	ctx, done, err := afs.lifecycle.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
 	return afs.recent.RecentEpisode(ctx)
 }
+
+// Home obtiene en una sola petición los animes recientes, los episodios recientes y los animes en emisión.
+// RecentAnime, RecentEpisode y OnAir se sirven desde esta misma instantánea y su entrada de caché.
+func (afs *AnimeflvService) Home(ctx context.Context) (dto.HomeSnapshot, error) {
+	ctx, done, err := afs.lifecycle.begin(ctx)
+	if err != nil {
+		return dto.HomeSnapshot{}, err
+	}
+	defer done()
+
+	return afs.recent.Home(ctx)
+}
+
+// OnAir obtiene la lista de animes actualmente en emisión.
+// Delega la operación al servicio de contenido reciente, que la toma de la instantánea de la página de inicio;
+// no tiene petición ni entrada de caché propias.
+func (afs *AnimeflvService) OnAir(ctx context.Context) ([]dto.AnimeRef, error) {
+	ctx, done, err := afs.lifecycle.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	return afs.recent.OnAir(ctx)
+}
+
+// validateDependencies verifica que deps contenga todo lo necesario para construir el servicio.
+func validateDependencies(deps Dependencies) error {
+	if deps.Scraper == nil {
+		return fmt.Errorf("el scraper no puede ser nil")
+	}
+	if deps.Config == nil {
+		return fmt.Errorf("la configuración no puede ser nil")
+	}
+	if deps.Config.EnableCache && deps.Cache == nil {
+		return fmt.Errorf("el caché está habilitado pero no se proporcionó un CachePort")
+	}
+	return nil
+}
+
+// CircuitState retorna el estado del circuit breaker del scraper. Mientras está abierto,
+// las operaciones se sirven desde las copias de respaldo del caché cuando existen.
+// Si el scraper no expone un circuit breaker (por ejemplo, uno inyectado), retorna dto.CircuitClosed.
+func (afs *AnimeflvService) CircuitState() dto.CircuitState {
+	if afs.circuit == nil {
+		return dto.CircuitClosed
+	}
+	return afs.circuit.CircuitState()
+}
+
+// RateLimits retorna el estado del límite de peticiones de cada clase de operación del scraper,
+// incluido su ritmo efectivo tras los 429 recientes.
+// Si el scraper no expone sus límites (por ejemplo, uno inyectado), retorna nil.
+func (afs *AnimeflvService) RateLimits() []dto.RateLimitState {
+	if afs.limits == nil {
+		return nil
+	}
+	return afs.limits.RateLimits()
+}
+
+// Close cierra el servicio de forma ordenada: rechaza nuevas operaciones con errs.ErrClosed,
+// espera a que terminen las operaciones en curso (incluidas sus escrituras en caché)
+// y libera los recursos propios, como la conexión a Valkey.
+// Si ctx expira antes de drenar, cancela las operaciones pendientes y retorna el error del contexto.
+func (afs *AnimeflvService) Close(ctx context.Context) error {
+	err := afs.lifecycle.close(ctx)
+	afs.logger.Debug().Err(err).Msg("Servicio AnimeFlv cerrado")
+	return err
+}
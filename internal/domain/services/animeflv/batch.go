@@ -0,0 +1,181 @@
+// Package animeflv - batch.go
+// Este archivo implementa las operaciones por lotes del servicio AnimeFlv.
+// Las consultas se reparten entre un número acotado de goroutines que comparten
+// el rate limiter del scraper, y los fallos se reportan por elemento para que un
+// ID inválido no haga fallar el lote completo.
+package animeflv
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+)
+
+// defaultBatchConcurrency es el número de consultas simultáneas si no se especifica otro.
+const defaultBatchConcurrency = 4
+
+// concurrency retorna el límite de concurrencia de opts o el valor por defecto.
+func concurrency(opts dto.BatchOptions) int {
+	if opts.Concurrency > 0 {
+		return opts.Concurrency
+	}
+	return defaultBatchConcurrency
+}
+
+// AnimeInfoMany obtiene la información de varios animes de forma concurrente.
+// Cada consulta pasa por AnimeInfo, por lo que aplica validación, caché y rate limiting.
+// Retorna un mapa con los resultados exitosos y otro con el error de cada ID fallido,
+// ambos indexados por el ID tal como fue recibido. Los IDs duplicados se consultan una vez.
+func (afs *AnimeflvService) AnimeInfoMany(ctx context.Context, ids []string, opts dto.BatchOptions) (map[string]dto.AnimeInfoResponse, map[string]error) {
+	results := make(map[string]dto.AnimeInfoResponse, len(ids))
+	failures := make(map[string]error)
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency(opts))
+	)
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			failures[id] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+
+		wg.Go(func() {
+			defer func() { <-sem }()
+
+			info, err := afs.AnimeInfo(ctx, id)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				failures[id] = err
+				return
+			}
+			results[id] = info
+		})
+	}
+
+	wg.Wait()
+	return results, failures
+}
+
+// LinksRange obtiene los enlaces de los episodios de idAnime comprendidos entre from y to (inclusive).
+// Usa AnimeInfo para conocer los números de episodio válidos, consulta los episodios de forma
+// concurrente bajo el rate limiter y entrega cada resultado en cuanto está disponible, por lo que
+// el orden no está garantizado. Cada episodio pasa por Links y queda cacheado individualmente.
+// Si un episodio falla, se entrega un LinkResponse con ID y Episode junto al error y la iteración continúa.
+func (afs *AnimeflvService) LinksRange(ctx context.Context, idAnime string, from, to uint) iter.Seq2[dto.LinkResponse, error] {
+	return func(yield func(dto.LinkResponse, error) bool) {
+		if from > to {
+			yield(dto.LinkResponse{ID: idAnime}, fmt.Errorf("%w: el episodio inicial %d es mayor que el final %d", errs.ErrInvalidInput, from, to))
+			return
+		}
+
+		afs.streamLinks(ctx, idAnime, func(episode uint) bool {
+			return episode >= from && episode <= to
+		}, yield)
+	}
+}
+
+// AllLinks obtiene los enlaces de todos los episodios disponibles de idAnime.
+// Se comporta igual que LinksRange sin límites de episodio.
+func (afs *AnimeflvService) AllLinks(ctx context.Context, idAnime string) iter.Seq2[dto.LinkResponse, error] {
+	return func(yield func(dto.LinkResponse, error) bool) {
+		afs.streamLinks(ctx, idAnime, func(uint) bool { return true }, yield)
+	}
+}
+
+// linkResult agrupa el resultado de la consulta de enlaces de un episodio.
+type linkResult struct {
+	links dto.LinkResponse
+	err   error
+}
+
+// streamLinks resuelve los episodios de idAnime que cumplen keep y entrega sus enlaces a yield.
+// Si el consumidor deja de iterar, cancela las consultas pendientes y espera a que terminen.
+// Si ctx se cancela, espera a que terminen las consultas en curso y entrega una última vez el
+// error del contexto, para distinguir un rango interrumpido de uno completo.
+func (afs *AnimeflvService) streamLinks(ctx context.Context, idAnime string, keep func(uint) bool, yield func(dto.LinkResponse, error) bool) {
+	info, err := afs.AnimeInfo(ctx, idAnime)
+	if err != nil {
+		yield(dto.LinkResponse{ID: idAnime}, err)
+		return
+	}
+
+	var episodes []uint
+	for _, ep := range info.EpisodeNumbers() {
+		if ep > 0 && keep(uint(ep)) {
+			episodes = append(episodes, uint(ep))
+		}
+	}
+	slices.Sort(episodes)
+
+	workCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan uint)
+	results := make(chan linkResult)
+
+	go func() {
+		defer close(jobs)
+		for _, ep := range episodes {
+			select {
+			case jobs <- ep:
+			case <-workCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range min(defaultBatchConcurrency, len(episodes)) {
+		wg.Go(func() {
+			for ep := range jobs {
+				links, err := afs.Links(workCtx, info.ID, ep)
+				if err != nil {
+					links = dto.LinkResponse{ID: info.ID, Episode: ep}
+				}
+				select {
+				case results <- linkResult{links: links, err: err}:
+				case <-workCtx.Done():
+					return
+				}
+			}
+		})
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		if !yield(result.links, result.err) {
+			cancel()
+			for range results {
+			}
+			return
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		yield(dto.LinkResponse{}, err)
+	}
+}
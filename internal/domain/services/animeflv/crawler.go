@@ -0,0 +1,138 @@
+// Package animeflv - crawler.go
+// Este archivo implementa el rastreo completo del catálogo. El rastreo recorre todas
+// las páginas de /browse para descubrir los IDs y luego consulta AnimeInfo de cada uno.
+// Tras cada paso guarda un checkpoint en un ports.CheckpointStore, de modo que un
+// rastreo interrumpido continúa donde se quedó. Todas las peticiones pasan por el
+// scraper compartido y, por tanto, por su rate limiter.
+package animeflv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+	"github.com/dst3v3n/api-anime/internal/ports"
+)
+
+// Crawl recorre el catálogo completo y entrega la información de cada anime a opts.OnAnime.
+// Si store contiene un checkpoint de un rastreo anterior sin terminar, lo reanuda.
+// Los animes inexistentes o con una página que no puede parsearse se registran en
+// CrawlCheckpoint.Failed y el rastreo continúa; cualquier otro error lo detiene dejando
+// el checkpoint intacto para reanudar más tarde. Al terminar, el checkpoint se elimina.
+func (afs *AnimeflvService) Crawl(ctx context.Context, store ports.CheckpointStore, opts dto.CrawlOptions) error {
+	if store == nil {
+		return fmt.Errorf("%w: el almacén de checkpoints no puede ser nil", errs.ErrInvalidInput)
+	}
+	if opts.OnAnime == nil {
+		return fmt.Errorf("%w: CrawlOptions.OnAnime no puede ser nil", errs.ErrInvalidInput)
+	}
+
+	checkpoint, found, err := store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	if !found || checkpoint.Phase == "" || checkpoint.Phase == dto.CrawlDone {
+		checkpoint = dto.CrawlCheckpoint{Phase: dto.CrawlBrowse, NextPage: 1}
+	} else {
+		afs.logger.Info().
+			Str("phase", string(checkpoint.Phase)).
+			Uint("page", checkpoint.NextPage).
+			Int("index", checkpoint.NextIndex).
+			Msg("Reanudando rastreo del catálogo")
+	}
+
+	progress := func(p dto.CrawlProgress) {
+		if opts.Progress != nil {
+			opts.Progress(p)
+		}
+	}
+	save := func() error {
+		checkpoint.UpdatedAt = time.Now()
+		return store.Save(ctx, checkpoint)
+	}
+
+	seen := make(map[string]bool, len(checkpoint.IDs))
+	for _, id := range checkpoint.IDs {
+		seen[id] = true
+	}
+
+	for checkpoint.Phase == dto.CrawlBrowse {
+		page, err := afs.Browse(ctx, dto.BrowseFilter{}, checkpoint.NextPage)
+		if err != nil {
+			return err
+		}
+
+		for _, anime := range page.Animes {
+			if !seen[anime.ID] {
+				seen[anime.ID] = true
+				checkpoint.IDs = append(checkpoint.IDs, anime.ID)
+			}
+		}
+		if page.TotalPages > 0 {
+			checkpoint.TotalPages = page.TotalPages
+		}
+
+		progress(dto.CrawlProgress{
+			Phase:      dto.CrawlBrowse,
+			Page:       checkpoint.NextPage,
+			TotalPages: checkpoint.TotalPages,
+			Total:      len(checkpoint.IDs),
+		})
+
+		if checkpoint.NextPage >= checkpoint.TotalPages {
+			checkpoint.Phase = dto.CrawlInfo
+		} else {
+			checkpoint.NextPage++
+		}
+		if err := save(); err != nil {
+			return err
+		}
+	}
+
+	for checkpoint.NextIndex < len(checkpoint.IDs) {
+		id := checkpoint.IDs[checkpoint.NextIndex]
+
+		info, err := afs.AnimeInfo(ctx, id)
+		var parseErr *errs.ParseError
+		switch {
+		case err == nil:
+			if err := opts.OnAnime(info); err != nil {
+				return err
+			}
+		case errors.Is(err, errs.ErrNotFound), errors.As(err, &parseErr):
+			checkpoint.Failed = append(checkpoint.Failed, id)
+		default:
+			return err
+		}
+
+		checkpoint.NextIndex++
+		progress(dto.CrawlProgress{
+			Phase:      dto.CrawlInfo,
+			TotalPages: checkpoint.TotalPages,
+			AnimeID:    id,
+			Done:       checkpoint.NextIndex,
+			Total:      len(checkpoint.IDs),
+			Err:        err,
+		})
+		if err := save(); err != nil {
+			return err
+		}
+	}
+
+	checkpoint.Phase = dto.CrawlDone
+	progress(dto.CrawlProgress{
+		Phase:      dto.CrawlDone,
+		TotalPages: checkpoint.TotalPages,
+		Done:       checkpoint.NextIndex,
+		Total:      len(checkpoint.IDs),
+	})
+	afs.logger.Info().
+		Int("animes", len(checkpoint.IDs)).
+		Int("failed", len(checkpoint.Failed)).
+		Msg("Rastreo del catálogo completado")
+
+	return store.Clear(ctx)
+}
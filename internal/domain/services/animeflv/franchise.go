@@ -0,0 +1,170 @@
+// Package animeflv - franchise.go
+// Este archivo implementa la resolución de franquicias. Recorre en anchura los animes
+// relacionados a partir de un anime, con detección de ciclos y un límite de profundidad,
+// y ordena los animes encontrados en un orden de visualización sugerido a partir de
+// las relaciones de secuela, precuela e historia paralela.
+package animeflv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+)
+
+// franchiseMaxDepth es el número máximo de saltos de relación que recorre Franchise.
+const franchiseMaxDepth = 4
+
+// franchiseNode es un anime descubierto durante el recorrido de la franquicia.
+type franchiseNode struct {
+	entry dto.FranchiseEntry
+	index int // Orden de descubrimiento; el anime de partida es 0
+}
+
+// Franchise resuelve la franquicia de idAnime recorriendo en anchura sus animes relacionados
+// hasta franchiseMaxDepth saltos. Cada nivel se consulta con AnimeInfoMany, por lo que aplica
+// caché y rate limiting. Los animes relacionados que no existen o no pueden parsearse se
+// incluyen solo con su ID y título y se listan en Unresolved; cualquier otro error detiene
+// la resolución.
+func (afs *AnimeflvService) Franchise(ctx context.Context, idAnime string) (dto.Franchise, error) {
+	root, err := afs.AnimeInfo(ctx, idAnime)
+	if err != nil {
+		return dto.Franchise{}, err
+	}
+
+	franchise := dto.Franchise{Root: root.ID}
+	nodes := map[string]*franchiseNode{
+		root.ID: {entry: dto.FranchiseEntry{Anime: root.AnimeStruct}},
+	}
+	order := []string{root.ID}
+
+	level := []dto.AnimeInfoResponse{root}
+	for depth := 0; len(level) > 0; depth++ {
+		var next []string
+		for _, info := range level {
+			for _, related := range info.AnimeRelated {
+				if related.ID == "" || related.ID == info.ID {
+					continue
+				}
+				if _, seen := nodes[related.ID]; !seen {
+					if depth == franchiseMaxDepth {
+						franchise.Truncated = true
+						continue
+					}
+					nodes[related.ID] = &franchiseNode{
+						entry: dto.FranchiseEntry{
+							Anime:    dto.AnimeStruct{ID: related.ID, Title: related.Title},
+							Depth:    depth + 1,
+							Relation: related.Type,
+						},
+						index: len(order),
+					}
+					order = append(order, related.ID)
+					next = append(next, related.ID)
+				}
+				franchise.Relations = append(franchise.Relations, dto.FranchiseRelation{
+					From:     info.ID,
+					To:       related.ID,
+					Type:     related.Type,
+					Category: related.Category,
+				})
+			}
+		}
+
+		if len(next) == 0 {
+			break
+		}
+
+		results, failures := afs.AnimeInfoMany(ctx, next, dto.BatchOptions{})
+		level = make([]dto.AnimeInfoResponse, 0, len(results))
+		for _, id := range next {
+			if info, ok := results[id]; ok {
+				nodes[id].entry.Anime = info.AnimeStruct
+				level = append(level, info)
+				continue
+			}
+
+			var parseErr *errs.ParseError
+			if err := failures[id]; !errors.Is(err, errs.ErrNotFound) && !errors.As(err, &parseErr) {
+				return dto.Franchise{}, fmt.Errorf("error al resolver la franquicia de %q en %q: %w", root.ID, id, err)
+			}
+			franchise.Unresolved = append(franchise.Unresolved, id)
+		}
+	}
+
+	franchise.WatchOrder = watchOrder(order, nodes, franchise.Relations)
+	return franchise, nil
+}
+
+// watchOrder ordena los animes de la franquicia respetando las relaciones entre ellos:
+// las precuelas e historias principales van antes y las secuelas, historias paralelas y
+// demás relaciones después. Entre los animes disponibles se elige el desbloqueado más
+// recientemente, dando prioridad a las historias paralelas sobre la línea principal para
+// verlas junto a la serie a la que pertenecen, y después el primero descubierto.
+// Si las relaciones forman un ciclo, se rompe con el anime con menos predecesores pendientes.
+func watchOrder(order []string, nodes map[string]*franchiseNode, relations []dto.FranchiseRelation) []dto.FranchiseEntry {
+	preds := make(map[string]map[string]bool, len(order))
+	mainline := map[string]bool{order[0]: true}
+	for _, relation := range relations {
+		before, after := relation.From, relation.To
+		switch relation.Type {
+		case dto.RelationPrequel, dto.RelationParentStory:
+			before, after = after, before
+		}
+		if relation.Type == dto.RelationSequel || relation.Type == dto.RelationPrequel {
+			mainline[relation.From] = true
+			mainline[relation.To] = true
+		}
+		if preds[after] == nil {
+			preds[after] = make(map[string]bool)
+		}
+		preds[after][before] = true
+	}
+
+	position := make(map[string]int, len(order))
+	entries := make([]dto.FranchiseEntry, 0, len(order))
+	for len(entries) < len(order) {
+		best, bestUnlock, bestRank, bestPending := "", 0, 0, 0
+		for _, id := range order {
+			if _, placed := position[id]; placed {
+				continue
+			}
+
+			unlock, pending := -1, 0
+			for pred := range preds[id] {
+				if pos, placed := position[pred]; placed {
+					unlock = max(unlock, pos)
+				} else {
+					pending++
+				}
+			}
+			rank := 0
+			if mainline[id] {
+				rank = 1
+			}
+
+			switch {
+			case best == "":
+			case pending != bestPending:
+				if pending > bestPending {
+					continue
+				}
+			case pending > 0:
+				continue
+			case unlock != bestUnlock:
+				if unlock < bestUnlock {
+					continue
+				}
+			case rank >= bestRank:
+				continue
+			}
+			best, bestUnlock, bestRank, bestPending = id, unlock, rank, pending
+		}
+
+		position[best] = len(entries)
+		entries = append(entries, nodes[best].entry)
+	}
+	return entries
+}
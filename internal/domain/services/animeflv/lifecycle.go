@@ -0,0 +1,93 @@
+// Package animeflv - lifecycle.go
+// Este archivo implementa el control del ciclo de vida del servicio AnimeFlv.
+// Registra las operaciones en curso para poder drenarlas durante el cierre,
+// rechaza nuevas operaciones una vez cerrado el servicio y libera los recursos
+// propios (conexión a Valkey, conexiones HTTP inactivas) al finalizar.
+package animeflv
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+)
+
+// lifecycle coordina las operaciones en curso y el cierre ordenado del servicio.
+// Cuando el plazo de cierre expira, cancela el contexto de las operaciones pendientes
+// para que abandonen la espera del rate limiter o la petición HTTP en curso.
+type lifecycle struct {
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
+	abort    context.Context
+	cancel   context.CancelFunc
+	onClose  []func()
+}
+
+// newLifecycle crea el controlador de ciclo de vida.
+// onClose contiene las funciones que liberan los recursos propios del servicio.
+func newLifecycle(onClose []func()) *lifecycle {
+	abort, cancel := context.WithCancel(context.Background())
+	return &lifecycle{
+		abort:   abort,
+		cancel:  cancel,
+		onClose: onClose,
+	}
+}
+
+// begin registra una operación en curso y retorna un contexto derivado que se cancela
+// si el cierre del servicio excede su plazo. La función retornada debe invocarse al
+// terminar la operación. Retorna errs.ErrClosed si el servicio ya fue cerrado.
+func (l *lifecycle) begin(ctx context.Context) (context.Context, func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return ctx, func() {}, errs.ErrClosed
+	}
+
+	l.inflight.Add(1)
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(l.abort, cancel)
+
+	return ctx, func() {
+		stop()
+		cancel()
+		l.inflight.Done()
+	}, nil
+}
+
+// close rechaza nuevas operaciones, espera a que terminen las que están en curso
+// y libera los recursos propios. Si ctx expira antes de drenar, cancela las operaciones
+// pendientes, espera su retorno y retorna el error del contexto. Es idempotente.
+func (l *lifecycle) close(ctx context.Context) error {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil
+	}
+	l.closed = true
+	l.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		l.inflight.Wait()
+		close(drained)
+	}()
+
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+		l.cancel()
+		<-drained
+	}
+	l.cancel()
+
+	for _, release := range l.onClose {
+		release()
+	}
+
+	return err
+}
@@ -1,76 +1,64 @@
 // Package animeflv - recent_service.go
 // Este archivo implementa el servicio para obtener contenido reciente.
 // Proporciona métodos para acceder a animes y episodios recientemente
-// agregados al sitio, utilizando caché distribuido para optimizar consultas frecuentes
-// y reducir la carga en el scraper.
+// agregados al sitio y a los animes en emisión. Todas se obtienen de una única
+// instantánea de la página de inicio que el middleware de caché del scraper
+// guarda bajo una sola clave.
 package animeflv
 
 import (
 	"context"
 
 	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
 	"github.com/dst3v3n/api-anime/internal/ports"
 )
 
 // recentService encapsula la lógica para obtener contenido reciente.
-// Implementa caché distribuido (Valkey) para almacenar temporalmente resultados
-// de animes y episodios recientes, mejorando el rendimiento de consultas repetidas.
+// Recibe el scraper ya envuelto con la cadena de middlewares (caché, logging, etc.).
+// Todas las secciones se sirven desde la instantánea de la página de inicio, de modo que
+// consultar varias de ellas cuesta una sola petición y una sola entrada de caché.
 type recentService struct {
-	scraper     ports.ScraperPort
-	cache       ports.CachePort
-	enableCache bool
+	scraper ports.ScraperPort
 }
 
-// RecentAnime obtiene la lista de animes recientemente agregados con caché.
-// Intenta recuperar del caché primero, y si no está disponible, consulta al scraper
-// y almacena el resultado en caché para futuras solicitudes.
-func (recent *recentService) RecentAnime(ctx context.Context) ([]dto.AnimeStruct, error) {
-	cacheKey := "recent-anime"
-
-	if recent.enableCache {
-		var result []dto.AnimeStruct
-		if err := recent.cache.Get(ctx, cacheKey, &result); err == nil {
-			if len(result) > 0 {
-				return result, nil
-			}
-		}
-	}
+// Home obtiene la instantánea completa de la página de inicio.
+func (recent *recentService) Home(ctx context.Context) (dto.HomeSnapshot, error) {
+	return recent.scraper.Home(ctx)
+}
 
-	result, err := recent.scraper.RecentAnime(ctx)
+// RecentAnime obtiene la lista de animes recientemente agregados.
+func (recent *recentService) RecentAnime(ctx context.Context) ([]dto.AnimeStruct, error) {
+	home, err := recent.scraper.Home(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	if recent.enableCache {
-		_ = recent.cache.Set(ctx, cacheKey, result)
+	if len(home.RecentAnime) == 0 {
+		return nil, &errs.ParseError{Page: errs.PageHome, Field: "animes"}
 	}
-
-	return result, nil
+	return home.RecentAnime, nil
 }
 
-// RecentEpisode obtiene la lista de episodios recientemente publicados con caché.
-// Intenta recuperar del caché primero, y si no está disponible, consulta al scraper
-// y almacena el resultado en caché para futuras solicitudes.
+// RecentEpisode obtiene la lista de episodios recientemente publicados.
 func (recent *recentService) RecentEpisode(ctx context.Context) ([]dto.EpisodeListResponse, error) {
-	cacheKey := "recent-episode"
-
-	if recent.enableCache {
-		var result []dto.EpisodeListResponse
-		if err := recent.cache.Get(ctx, cacheKey, &result); err == nil {
-			if len(result) > 0 {
-				return result, nil
-			}
-		}
+	home, err := recent.scraper.Home(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(home.RecentEpisodes) == 0 {
+		return nil, &errs.ParseError{Page: errs.PageHome, Field: "episodes"}
 	}
+	return home.RecentEpisodes, nil
+}
 
-	result, err := recent.scraper.RecentEpisode(ctx)
+// OnAir obtiene la lista de animes actualmente en emisión.
+func (recent *recentService) OnAir(ctx context.Context) ([]dto.AnimeRef, error) {
+	home, err := recent.scraper.Home(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	if recent.enableCache {
-		_ = recent.cache.Set(ctx, cacheKey, result)
+	if len(home.OnAir) == 0 {
+		return nil, &errs.ParseError{Page: errs.PageHome, Field: "onair"}
 	}
-
-	return result, nil
+	return home.OnAir, nil
 }
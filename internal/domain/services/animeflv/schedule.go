@@ -0,0 +1,194 @@
+// Package animeflv - schedule.go
+// Este archivo implementa el calendario semanal de emisión. Resuelve los animes en
+// emisión de la página de inicio, lee la fecha del próximo episodio de cada uno y
+// agrupa las emisiones por día. La información de cada serie se guarda en un índice
+// en memoria, propio de cada instancia del servicio, que se refresca de forma incremental:
+// solo se vuelven a consultar las series nuevas, las que ya emitieron su próximo episodio,
+// las que superan el intervalo de refresco o las que no pudieron consultarse antes.
+package animeflv
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+)
+
+const (
+	// scheduleRefreshInterval es la antigüedad máxima de la información de una serie en el índice.
+	scheduleRefreshInterval = 6 * time.Hour
+
+	// nextEpisodeLayout es el formato de la fecha del próximo episodio en "var anime_info".
+	nextEpisodeLayout = "2006-01-02"
+)
+
+// scheduleItem es la información de una serie en emisión guardada en el índice del calendario.
+type scheduleItem struct {
+	anime       dto.AnimeRef
+	nextEpisode time.Time // Fecha del próximo episodio (en UTC, sin hora); cero si no se conoce
+	nextNumber  int       // Número del próximo episodio; 0 si no se conoce
+	fetchedAt   time.Time
+}
+
+// stale reporta si la información de la serie debe volver a consultarse.
+func (item scheduleItem) stale(now time.Time) bool {
+	if now.Sub(item.fetchedAt) >= scheduleRefreshInterval {
+		return true
+	}
+	return !item.nextEpisode.IsZero() && inLocation(item.nextEpisode, now.Location()).Before(midnight(now))
+}
+
+// scheduleIndex guarda la información de las series en emisión entre llamadas a Schedule.
+type scheduleIndex struct {
+	mu    sync.Mutex
+	items map[string]scheduleItem
+}
+
+// newScheduleIndex crea un índice de calendario vacío.
+func newScheduleIndex() *scheduleIndex {
+	return &scheduleIndex{items: make(map[string]scheduleItem)}
+}
+
+// Schedule retorna el calendario de emisión de la semana que comienza en weekStart.
+// Si weekStart es cero se usa el día actual. Las series que emiten semanalmente se proyectan
+// a semanas posteriores a la fecha de su próximo episodio. Las series cuya información no
+// puede obtenerse conservan la última información conocida o se omiten del calendario;
+// solo retorna un error si el índice no tiene información de ninguna serie en emisión.
+func (afs *AnimeflvService) Schedule(ctx context.Context, weekStart time.Time) (dto.Schedule, error) {
+	onAir, err := afs.OnAir(ctx)
+	if err != nil {
+		return dto.Schedule{}, err
+	}
+
+	if err := afs.refreshSchedule(ctx, onAir, time.Now()); err != nil {
+		return dto.Schedule{}, err
+	}
+
+	if weekStart.IsZero() {
+		weekStart = time.Now()
+	}
+	return afs.schedule.build(midnight(weekStart)), nil
+}
+
+// refreshSchedule actualiza el índice con los animes en emisión, consultando solo las series
+// nuevas o desactualizadas y descartando las que ya no están en emisión. Las series que fallan
+// conservan su información anterior y se vuelven a consultar en la siguiente llamada. Retorna
+// el error del contexto si se canceló, o el de la primera serie si fallaron todas las pendientes
+// y el índice no tiene información de ninguna otra; en otro caso los fallos solo se registran.
+func (afs *AnimeflvService) refreshSchedule(ctx context.Context, onAir []dto.AnimeRef, now time.Time) error {
+	index := afs.schedule
+
+	index.mu.Lock()
+	var pending []string
+	current := make(map[string]dto.AnimeRef, len(onAir))
+	for _, anime := range onAir {
+		current[anime.ID] = anime
+		if item, ok := index.items[anime.ID]; !ok || item.stale(now) {
+			pending = append(pending, anime.ID)
+		}
+	}
+	for id := range index.items {
+		if _, ok := current[id]; !ok {
+			delete(index.items, id)
+		}
+	}
+	known := len(index.items)
+	index.mu.Unlock()
+
+	infos, failures := afs.AnimeInfoMany(ctx, pending, dto.BatchOptions{})
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(pending) > 0 && len(infos) == 0 && known == 0 {
+		return fmt.Errorf("no se pudo consultar ninguna de las %d series en emisión: %w", len(pending), failures[pending[0]])
+	}
+	for id, err := range failures {
+		afs.logger.Warn().Err(err).Str("id", id).Msg("No se pudo actualizar la serie del calendario")
+	}
+
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	for id, info := range infos {
+		item := scheduleItem{anime: current[id], fetchedAt: now}
+		if date, err := time.Parse(nextEpisodeLayout, info.NextEpisode); err == nil {
+			item.nextEpisode = date
+		}
+		if len(info.Episodes) > 0 {
+			item.nextNumber = slices.Max(info.EpisodeNumbers()) + 1
+		}
+		index.items[id] = item
+	}
+
+	afs.logger.Debug().
+		Int("onAir", len(onAir)).
+		Int("refreshed", len(infos)).
+		Int("failed", len(failures)).
+		Msg("Calendario de emisión actualizado")
+	return nil
+}
+
+// build agrupa las emisiones del índice en los 7 días que comienzan en weekStart.
+func (index *scheduleIndex) build(weekStart time.Time) dto.Schedule {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+
+	schedule := dto.Schedule{WeekStart: weekStart}
+	for i := range 7 {
+		date := weekStart.AddDate(0, 0, i)
+		schedule.Days = append(schedule.Days, dto.ScheduleDay{Weekday: date.Weekday(), Date: date})
+	}
+
+	weekEnd := weekStart.AddDate(0, 0, 7)
+	for _, item := range index.items {
+		if schedule.UpdatedAt.IsZero() || item.fetchedAt.Before(schedule.UpdatedAt) {
+			schedule.UpdatedAt = item.fetchedAt
+		}
+		if item.nextEpisode.IsZero() {
+			continue
+		}
+
+		// Las series en emisión se asumen semanales: se proyecta el próximo episodio a la semana pedida.
+		airDate, weeks := inLocation(item.nextEpisode, weekStart.Location()), 0
+		for airDate.Before(weekStart) {
+			airDate = airDate.AddDate(0, 0, 7)
+			weeks++
+		}
+		if !airDate.Before(weekEnd) {
+			continue
+		}
+
+		entry := dto.ScheduleEntry{Anime: item.anime, AirDate: airDate}
+		if item.nextNumber > 0 {
+			entry.Episode = item.nextNumber + weeks
+		}
+		for i := range schedule.Days {
+			if schedule.Days[i].Date.Equal(airDate) {
+				schedule.Days[i].Entries = append(schedule.Days[i].Entries, entry)
+				break
+			}
+		}
+	}
+
+	for i := range schedule.Days {
+		slices.SortFunc(schedule.Days[i].Entries, func(a, b dto.ScheduleEntry) int {
+			return strings.Compare(a.Anime.Title, b.Anime.Title)
+		})
+	}
+	return schedule
+}
+
+// midnight retorna el inicio del día de t en su zona horaria.
+func midnight(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// inLocation retorna la medianoche de la fecha de date en la zona horaria loc.
+func inLocation(date time.Time, loc *time.Location) time.Time {
+	year, month, day := date.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
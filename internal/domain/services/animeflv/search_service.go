@@ -1,34 +1,33 @@
 // Package animeflv - search_service.go
 // Este archivo implementa el servicio de búsqueda de animes.
-// Contiene la lógica de negocio para validar parámetros de búsqueda,
-// normalizar entradas (convertir a minúsculas, manejar paginación),
-// implementar caché distribuido y delegar al scraper para obtener los resultados.
+// Contiene la lógica de negocio para validar parámetros de búsqueda y
+// normalizar entradas (convertir a minúsculas, manejar paginación) antes de
+// delegar al scraper, cuyo middleware de caché almacena los resultados por página.
 package animeflv
 
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
 	"github.com/dst3v3n/api-anime/internal/ports"
 )
 
 // searchService encapsula la lógica de búsqueda de animes.
-// Implementa caché distribuido (Valkey) para optimizar búsquedas frecuentes
-// y reduce la carga al scraper mediante almacenamiento de resultados por página.
+// Recibe el scraper ya envuelto con la cadena de middlewares (caché, logging, etc.).
 type searchService struct {
-	scraper     ports.ScraperPort
-	cache       ports.CachePort
-	enableCache bool
+	scraper ports.ScraperPort
 }
 
-// SearchAnime realiza una búsqueda de animes con validaciones, transformaciones y caché.
+// SearchAnime realiza una búsqueda de animes con validaciones y transformaciones.
 // Valida que el nombre no esté vacío, normaliza el texto a minúsculas, maneja
-// la paginación por defecto, intenta recuperar del caché y consulta al scraper si es necesario.
+// la paginación por defecto y consulta al scraper.
 func (search *searchService) SearchAnime(ctx context.Context, anime string, page uint) (dto.AnimeResponse, error) {
 	if anime == "" {
-		return dto.AnimeResponse{}, fmt.Errorf("el nombre del anime no puede estar vacío")
+		return dto.AnimeResponse{}, fmt.Errorf("%w: el nombre del anime no puede estar vacío", errs.ErrInvalidInput)
 	}
 	anime = strings.ToLower(anime)
 	anime = strings.ReplaceAll(anime, " ", "-")
@@ -38,54 +37,55 @@ func (search *searchService) SearchAnime(ctx context.Context, anime string, page
 	}
 	pageStr := fmt.Sprintf("%d", page)
 
-	cacheKey := fmt.Sprintf("search-anime-%s-page-%d", anime, page)
-
-	var result dto.AnimeResponse
+	return search.scraper.SearchAnime(ctx, anime, pageStr)
+}
 
-	if search.enableCache {
-		if err := search.cache.Get(ctx, cacheKey, &result); err == nil {
-			if len(result.Animes) > 0 {
-				return result, nil
-			}
+// Browse consulta el catálogo con filtros. Normaliza los slugs de género a minúsculas,
+// descarta los valores vacíos o duplicados, valida los años y maneja la paginación por defecto.
+func (search *searchService) Browse(ctx context.Context, filter dto.BrowseFilter, page uint) (dto.AnimeResponse, error) {
+	normalized := dto.BrowseFilter{Order: filter.Order}
+	for _, genre := range filter.Genres {
+		genre = strings.ToLower(strings.TrimSpace(genre))
+		if genre != "" && !slices.Contains(normalized.Genres, genre) {
+			normalized.Genres = append(normalized.Genres, genre)
 		}
 	}
-
-	result, err := search.scraper.SearchAnime(ctx, anime, pageStr)
-	if err != nil {
-		return result, err
+	for _, year := range filter.Years {
+		if year <= 0 {
+			return dto.AnimeResponse{}, fmt.Errorf("%w: año inválido %d", errs.ErrInvalidInput, year)
+		}
+		if !slices.Contains(normalized.Years, year) {
+			normalized.Years = append(normalized.Years, year)
+		}
 	}
-
-	if search.enableCache {
-		_ = search.cache.Set(ctx, cacheKey, result)
+	for _, tipo := range filter.Types {
+		if !slices.Contains(normalized.Types, tipo) {
+			normalized.Types = append(normalized.Types, tipo)
+		}
 	}
-
-	return result, nil
-}
-
-// Search obtiene todos los animes disponibles sin filtros de búsqueda con caché.
-// Intenta recuperar del caché primero, y si no está disponible, consulta al scraper
-// y almacena el resultado en caché para futuras solicitudes.
-func (search *searchService) Search(ctx context.Context) (dto.AnimeResponse, error) {
-	catcheKey := "search-anime-all"
-
-	var result dto.AnimeResponse
-
-	if search.enableCache {
-		if err := search.cache.Get(ctx, catcheKey, &result); err == nil {
-			if len(result.Animes) > 0 {
-				return result, nil
-			}
+	for _, status := range filter.Status {
+		if !slices.Contains(normalized.Status, status) {
+			normalized.Status = append(normalized.Status, status)
 		}
 	}
-
-	result, err := search.scraper.Search(ctx)
-	if err != nil {
-		return result, err
+	if normalized.Order == dto.OrderDefault {
+		normalized.Order = ""
 	}
 
-	if search.enableCache {
-		_ = search.cache.Set(ctx, catcheKey, result)
+	if page == 0 {
+		page = 1
 	}
+	pageStr := fmt.Sprintf("%d", page)
 
-	return result, nil
+	return search.scraper.Browse(ctx, normalized, pageStr)
+}
+
+// Genres obtiene el catálogo de géneros válidos para Browse.
+func (search *searchService) Genres(ctx context.Context) ([]dto.Genre, error) {
+	return search.scraper.Genres(ctx)
+}
+
+// Search obtiene todos los animes disponibles sin filtros de búsqueda.
+func (search *searchService) Search(ctx context.Context) (dto.AnimeResponse, error) {
+	return search.scraper.Search(ctx)
 }
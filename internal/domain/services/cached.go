@@ -0,0 +1,141 @@
+// Package services contiene componentes de dominio compartidos por todos los proveedores
+// de anime (AnimeFlv y futuros). Este archivo (cached.go) implementa Cached, la capa
+// cache-aside tipada que centraliza la construcción de claves, la detección de resultados
+// vacíos, la TTL, la deserialización y la política de errores del caché. Los servicios de
+// dominio no usan el caché directamente: CachingMiddleware declara un Cached por operación
+// del scraper (prefijo de clave, criterio de vacío y TTL), de modo que todos los proveedores
+// se comporten igual ante el caché. Las operaciones que lo activan con WithStale guardan
+// además una copia de larga duración de cada valor, que se sirve cuando el circuit breaker
+// del scraper está abierto.
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+	"github.com/dst3v3n/api-anime/internal/ports"
+)
+
+const (
+	// staleTTL es la TTL de la copia de respaldo de los valores de un Cached con WithStale,
+	// servida mientras el circuit breaker del scraper está abierto aunque la entrada normal
+	// haya expirado.
+	staleTTL = 7 * 24 * time.Hour
+
+	// stalePrefix es el prefijo de las claves de las copias de respaldo.
+	stalePrefix = "stale-"
+)
+
+// Cached implementa la estrategia cache-aside para valores de tipo T.
+// Política de errores:
+//   - Un fallo de lectura del caché (incluido errs.ErrCacheMiss) se trata como ausencia y se consulta la fuente.
+//   - Un fallo de escritura del caché se ignora; el resultado obtenido se retorna igualmente.
+//   - Los errores de la fuente se propagan y nunca se cachean; en particular, una página de
+//     verificación anti-bot (errs.ErrChallenged) nunca reemplaza un resultado válido en caché.
+//   - Los resultados vacíos según isEmpty no se cachean ni se sirven desde caché.
+//   - Si se activó WithStale y la fuente falla con errs.ErrCircuitOpen, se sirve la copia de
+//     respaldo ("stale-{clave}"), guardada con una TTL de 7 días solo si el caché implementa
+//     ports.CacheTTLPort.
+type Cached[T any] struct {
+	cache   ports.CachePort
+	enabled bool
+	prefix  string
+	isEmpty func(T) bool
+	ttl     time.Duration
+	stale   bool
+}
+
+// NewCached crea una capa cache-aside para valores de tipo T.
+// prefix es el prefijo de todas las claves generadas con Key.
+// isEmpty decide si un valor está vacío; si es nil, ningún valor se considera vacío.
+// Si enabled es false o cache es nil, Get consulta siempre la fuente.
+func NewCached[T any](cache ports.CachePort, enabled bool, prefix string, isEmpty func(T) bool) *Cached[T] {
+	if isEmpty == nil {
+		isEmpty = func(T) bool { return false }
+	}
+	return &Cached[T]{
+		cache:   cache,
+		enabled: enabled && cache != nil,
+		prefix:  prefix,
+		isEmpty: isEmpty,
+	}
+}
+
+// WithTTL establece una TTL propia para los valores guardados y retorna el mismo Cached.
+// Solo tiene efecto si el caché implementa ports.CacheTTLPort; en otro caso se usa Set.
+func (c *Cached[T]) WithTTL(ttl time.Duration) *Cached[T] {
+	c.ttl = ttl
+	return c
+}
+
+// WithStale activa la copia de respaldo de los valores guardados y retorna el mismo Cached.
+// Cada escritura guarda también "stale-{clave}" con una TTL de 7 días (o la TTL propia si es
+// mayor), lo que duplica las escrituras y el espacio ocupado por la operación; conviene
+// activarla solo en operaciones con un número acotado de claves.
+// Solo tiene efecto si el caché implementa ports.CacheTTLPort.
+func (c *Cached[T]) WithStale() *Cached[T] {
+	c.stale = true
+	return c
+}
+
+// Key construye una clave de caché uniendo el prefijo y las partes con guiones.
+// Ejemplo: Key("naruto", "page", 2) con prefijo "search-anime" -> "search-anime-naruto-page-2".
+func (c *Cached[T]) Key(parts ...any) string {
+	if len(parts) == 0 {
+		return c.prefix
+	}
+
+	segments := make([]string, 0, len(parts)+1)
+	segments = append(segments, c.prefix)
+	for _, part := range parts {
+		segments = append(segments, fmt.Sprint(part))
+	}
+	return strings.Join(segments, "-")
+}
+
+// Get retorna el valor cacheado bajo key o, si no existe, lo obtiene con fetch y lo guarda.
+func (c *Cached[T]) Get(ctx context.Context, key string, fetch func(ctx context.Context) (T, error)) (T, error) {
+	if c.enabled {
+		var cached T
+		if err := c.cache.Get(ctx, key, &cached); err == nil && !c.isEmpty(cached) {
+			return cached, nil
+		}
+	}
+
+	result, err := fetch(ctx)
+	if err != nil {
+		if c.enabled && c.stale && errors.Is(err, errs.ErrCircuitOpen) {
+			var stale T
+			if staleErr := c.cache.Get(ctx, stalePrefix+key, &stale); staleErr == nil && !c.isEmpty(stale) {
+				return stale, nil
+			}
+		}
+		return result, err
+	}
+
+	if c.enabled && !c.isEmpty(result) {
+		_ = c.set(ctx, key, result, c.ttl)
+		if _, ok := c.cache.(ports.CacheTTLPort); ok && c.stale {
+			_ = c.set(ctx, stalePrefix+key, result, max(c.ttl, staleTTL))
+		}
+	}
+
+	return result, nil
+}
+
+// set guarda value con la TTL indicada si es positiva y el caché la soporta.
+func (c *Cached[T]) set(ctx context.Context, key string, value T, ttl time.Duration) error {
+	if ttlCache, ok := c.cache.(ports.CacheTTLPort); ok && ttl > 0 {
+		return ttlCache.SetWithTTL(ctx, key, value, ttl)
+	}
+	return c.cache.Set(ctx, key, value)
+}
+
+// IsEmptySlice reporta si un slice no tiene elementos. Útil como isEmpty para listados.
+func IsEmptySlice[E any](s []E) bool {
+	return len(s) == 0
+}
@@ -0,0 +1,157 @@
+// Package services - caching_scraper.go
+// Este archivo implementa el middleware de caché del scraper. Aplica la estrategia
+// cache-aside de Cached a cada operación de ScraperPort, con una clave por operación
+// y parámetros, de modo que todos los proveedores cacheen de la misma forma.
+package services
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/ports"
+)
+
+// genresTTL es la TTL del catálogo de géneros, que casi nunca cambia.
+const genresTTL = 24 * time.Hour
+
+// cachingScraper decora un ScraperPort sirviendo sus resultados desde caché.
+type cachingScraper struct {
+	next      ports.ScraperPort
+	search    *Cached[dto.AnimeResponse]
+	listing   *Cached[dto.AnimeResponse]
+	browse    *Cached[dto.AnimeResponse]
+	catalog   *Cached[dto.AnimeResponse]
+	genres    *Cached[[]dto.Genre]
+	animeInfo *Cached[dto.AnimeInfoResponse]
+	links     *Cached[dto.LinkResponse]
+	home      *Cached[dto.HomeSnapshot]
+}
+
+// CachingMiddleware retorna un middleware que cachea los resultados del scraper.
+// Las claves generadas son:
+//   - "search-anime-{nombre}-page-{N}" y "search-anime-all"
+//   - "browse-{filtro canónico}-page-{N}" y "genres" (con TTL de 24 horas)
+//   - "anime-info-{id}" y "links-{id}-{episodio}"
+//   - "home", la instantánea de la página de inicio de la que se sirven los animes y
+//     episodios recientes y los animes en emisión
+//
+// El listado completo, las páginas del catálogo sin filtros, los géneros, la información de
+// anime, los enlaces y la página de inicio guardan además una copia de respaldo
+// "stale-{clave}" que se sirve con el circuit breaker abierto. Las búsquedas por nombre y las
+// páginas del catálogo filtrado no la guardan: sus claves dependen de términos y filtros arbitrarios.
+//
+// Si enabled es false o cache es nil, las llamadas pasan directamente al scraper.
+func CachingMiddleware(cache ports.CachePort, enabled bool) ports.ScraperMiddleware {
+	return func(next ports.ScraperPort) ports.ScraperPort {
+		return &cachingScraper{
+			next:    next,
+			search:  NewCached(cache, enabled, "search-anime", isEmptyAnimeResponse),
+			listing: NewCached(cache, enabled, "search-anime", isEmptyAnimeResponse).WithStale(),
+			browse:  NewCached(cache, enabled, "browse", isEmptyAnimeResponse),
+			catalog: NewCached(cache, enabled, "browse", isEmptyAnimeResponse).WithStale(),
+			genres:  NewCached(cache, enabled, "genres", IsEmptySlice[dto.Genre]).WithTTL(genresTTL).WithStale(),
+			animeInfo: NewCached(cache, enabled, "anime-info", func(result dto.AnimeInfoResponse) bool {
+				return len(result.Title) == 0
+			}).WithStale(),
+			links: NewCached(cache, enabled, "links", func(result dto.LinkResponse) bool {
+				return len(result.Link) == 0
+			}).WithStale(),
+			home: NewCached(cache, enabled, "home", func(result dto.HomeSnapshot) bool {
+				return len(result.RecentAnime) == 0 && len(result.RecentEpisodes) == 0
+			}).WithStale(),
+		}
+	}
+}
+
+// SearchAnime retorna la página de búsqueda cacheada o la obtiene del scraper.
+func (c *cachingScraper) SearchAnime(ctx context.Context, anime string, page string) (dto.AnimeResponse, error) {
+	return c.search.Get(ctx, c.search.Key(anime, "page", page), func(ctx context.Context) (dto.AnimeResponse, error) {
+		return c.next.SearchAnime(ctx, anime, page)
+	})
+}
+
+// Search retorna el listado completo cacheado o lo obtiene del scraper.
+func (c *cachingScraper) Search(ctx context.Context) (dto.AnimeResponse, error) {
+	return c.listing.Get(ctx, c.listing.Key("all"), c.next.Search)
+}
+
+// Browse retorna la página del catálogo filtrado cacheada o la obtiene del scraper.
+// Las páginas sin filtros, como las que recorre Crawl, se cachean con copia de respaldo.
+func (c *cachingScraper) Browse(ctx context.Context, filter dto.BrowseFilter, page string) (dto.AnimeResponse, error) {
+	parts := browseKeyParts(filter)
+	cached := c.browse
+	if len(parts) == 0 {
+		cached = c.catalog
+	}
+	return cached.Get(ctx, cached.Key(append(parts, "page", page)...), func(ctx context.Context) (dto.AnimeResponse, error) {
+		return c.next.Browse(ctx, filter, page)
+	})
+}
+
+// Genres retorna el catálogo de géneros cacheado o lo obtiene del scraper.
+func (c *cachingScraper) Genres(ctx context.Context) ([]dto.Genre, error) {
+	return c.genres.Get(ctx, c.genres.Key(), c.next.Genres)
+}
+
+// isEmptyAnimeResponse reporta si un listado de animes no tiene resultados.
+func isEmptyAnimeResponse(result dto.AnimeResponse) bool {
+	return len(result.Animes) == 0
+}
+
+// browseKeyParts genera la representación canónica de un filtro del catálogo.
+// Los valores de cada campo se ordenan para que filtros equivalentes compartan clave;
+// los campos vacíos se omiten.
+func browseKeyParts(filter dto.BrowseFilter) []any {
+	var parts []any
+	add := func(name string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		slices.Sort(values)
+		parts = append(parts, name, strings.Join(values, ","))
+	}
+
+	add("genre", slices.Clone(filter.Genres))
+	years := make([]string, 0, len(filter.Years))
+	for _, year := range filter.Years {
+		years = append(years, strconv.Itoa(year))
+	}
+	add("year", years)
+	types := make([]string, 0, len(filter.Types))
+	for _, tipo := range filter.Types {
+		types = append(types, string(tipo))
+	}
+	add("type", types)
+	status := make([]string, 0, len(filter.Status))
+	for _, s := range filter.Status {
+		status = append(status, strconv.Itoa(int(s)))
+	}
+	add("status", status)
+	if filter.Order != "" {
+		parts = append(parts, "order", string(filter.Order))
+	}
+	return parts
+}
+
+// AnimeInfo retorna la información del anime cacheada o la obtiene del scraper.
+func (c *cachingScraper) AnimeInfo(ctx context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+	return c.animeInfo.Get(ctx, c.animeInfo.Key(idAnime), func(ctx context.Context) (dto.AnimeInfoResponse, error) {
+		return c.next.AnimeInfo(ctx, idAnime)
+	})
+}
+
+// Links retorna los enlaces del episodio cacheados o los obtiene del scraper.
+func (c *cachingScraper) Links(ctx context.Context, idAnime string, episode uint) (dto.LinkResponse, error) {
+	return c.links.Get(ctx, c.links.Key(idAnime, episode), func(ctx context.Context) (dto.LinkResponse, error) {
+		return c.next.Links(ctx, idAnime, episode)
+	})
+}
+
+// Home retorna la instantánea de la página de inicio cacheada o la obtiene del scraper.
+func (c *cachingScraper) Home(ctx context.Context) (dto.HomeSnapshot, error) {
+	return c.home.Get(ctx, c.home.Key(), c.next.Home)
+}
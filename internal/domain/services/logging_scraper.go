@@ -0,0 +1,104 @@
+// Package services - logging_scraper.go
+// Este archivo implementa el middleware de logging del scraper. Registra cada operación
+// de ScraperPort con su duración y, si falla, con el error producido.
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/ports"
+	"github.com/rs/zerolog"
+)
+
+// loggingScraper decora un ScraperPort registrando cada llamada.
+type loggingScraper struct {
+	next   ports.ScraperPort
+	logger zerolog.Logger
+}
+
+// LoggingMiddleware retorna un middleware que registra cada operación del scraper.
+// Las llamadas exitosas se registran en nivel Debug y las fallidas en nivel Warn.
+func LoggingMiddleware(logger zerolog.Logger) ports.ScraperMiddleware {
+	return func(next ports.ScraperPort) ports.ScraperPort {
+		return &loggingScraper{
+			next:   next,
+			logger: logger,
+		}
+	}
+}
+
+// event crea el evento de log de una operación según su resultado.
+func (l *loggingScraper) event(operation string, start time.Time, err error) *zerolog.Event {
+	event := l.logger.Debug()
+	if err != nil {
+		event = l.logger.Warn().Err(err)
+	}
+	return event.Str("operation", operation).Dur("duration", time.Since(start))
+}
+
+// SearchAnime registra la búsqueda delegada en el scraper.
+func (l *loggingScraper) SearchAnime(ctx context.Context, anime string, page string) (dto.AnimeResponse, error) {
+	start := time.Now()
+	result, err := l.next.SearchAnime(ctx, anime, page)
+	l.event("SearchAnime", start, err).Str("anime", anime).Str("page", page).Msg("Scraper")
+	return result, err
+}
+
+// Search registra el listado completo delegado en el scraper.
+func (l *loggingScraper) Search(ctx context.Context) (dto.AnimeResponse, error) {
+	start := time.Now()
+	result, err := l.next.Search(ctx)
+	l.event("Search", start, err).Msg("Scraper")
+	return result, err
+}
+
+// Browse registra la consulta del catálogo filtrado delegada en el scraper.
+func (l *loggingScraper) Browse(ctx context.Context, filter dto.BrowseFilter, page string) (dto.AnimeResponse, error) {
+	start := time.Now()
+	result, err := l.next.Browse(ctx, filter, page)
+	l.event("Browse", start, err).
+		Strs("genres", filter.Genres).
+		Ints("years", filter.Years).
+		Str("order", string(filter.Order)).
+		Str("page", page).
+		Msg("Scraper")
+	return result, err
+}
+
+// Genres registra la consulta del catálogo de géneros delegada en el scraper.
+func (l *loggingScraper) Genres(ctx context.Context) ([]dto.Genre, error) {
+	start := time.Now()
+	result, err := l.next.Genres(ctx)
+	l.event("Genres", start, err).Int("count", len(result)).Msg("Scraper")
+	return result, err
+}
+
+// AnimeInfo registra la consulta de información delegada en el scraper.
+func (l *loggingScraper) AnimeInfo(ctx context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+	start := time.Now()
+	result, err := l.next.AnimeInfo(ctx, idAnime)
+	l.event("AnimeInfo", start, err).Str("id", idAnime).Msg("Scraper")
+	return result, err
+}
+
+// Links registra la consulta de enlaces delegada en el scraper.
+func (l *loggingScraper) Links(ctx context.Context, idAnime string, episode uint) (dto.LinkResponse, error) {
+	start := time.Now()
+	result, err := l.next.Links(ctx, idAnime, episode)
+	l.event("Links", start, err).Str("id", idAnime).Uint("episode", episode).Msg("Scraper")
+	return result, err
+}
+
+// Home registra la consulta de la página de inicio delegada en el scraper.
+func (l *loggingScraper) Home(ctx context.Context) (dto.HomeSnapshot, error) {
+	start := time.Now()
+	result, err := l.next.Home(ctx)
+	l.event("Home", start, err).
+		Int("recentAnime", len(result.RecentAnime)).
+		Int("recentEpisodes", len(result.RecentEpisodes)).
+		Int("onAir", len(result.OnAir)).
+		Msg("Scraper")
+	return result, err
+}
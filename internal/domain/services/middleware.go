@@ -0,0 +1,19 @@
+// Package services - middleware.go
+// Este archivo implementa el encadenamiento de middlewares alrededor de un ScraperPort.
+// Permite componer decoradores (caché, logging, métricas, auditoría) entre los servicios
+// de dominio y el scraper HTTP sin modificar ninguno de los dos.
+package services
+
+import "github.com/dst3v3n/api-anime/internal/ports"
+
+// Chain envuelve scraper con los middlewares indicados.
+// El primer middleware es el más externo: recibe primero cada llamada de los servicios
+// y el último es el más cercano al scraper original.
+func Chain(scraper ports.ScraperPort, middlewares ...ports.ScraperMiddleware) ports.ScraperPort {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] != nil {
+			scraper = middlewares[i](scraper)
+		}
+	}
+	return scraper
+}
@@ -4,7 +4,11 @@
 // realistas para validar comportamientos sin hacer scraping real.
 package mocks
 
-import "github.com/dst3v3n/api-anime/internal/domain/dto"
+import (
+	"fmt"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+)
 
 // MockAnimeStruct retorna un anime de ejemplo con datos completos.
 func MockAnimeStruct() dto.AnimeStruct {
@@ -80,12 +84,14 @@ func MockAnimeInfoResponse() dto.AnimeInfoResponse {
 			{
 				ID:       "one-piece-film-red",
 				Title:    "One Piece Film: Red",
-				Category: "Película",
+				Category: "Historia Paralela",
+				Type:     dto.RelationSideStory,
 			},
 			{
 				ID:       "one-piece-special-3d",
 				Title:    "One Piece 3D: Mugiwara Chase",
-				Category: "Especial",
+				Category: "Historia Paralela",
+				Type:     dto.RelationSideStory,
 			},
 		},
 		Genres: []string{
@@ -98,7 +104,11 @@ func MockAnimeInfoResponse() dto.AnimeInfoResponse {
 		},
 		Status:      dto.Emision,
 		NextEpisode: "2024-01-14",
-		Episodes:    generateEpisodeNumbers(1, 1090),
+		Episodes:    MockEpisodes("one-piece-tv", 130, generateEpisodeNumbers(1, 1090)...),
+		AltTitles:   []string{"ONE PIECE", "ワンピース"},
+		Votes:       52340,
+		Followers:   310482,
+		Banner:      "/uploads/animes/banners/130.jpg",
 	}
 }
 
@@ -118,6 +128,7 @@ func MockAnimeInfoResponseFinished() dto.AnimeInfoResponse {
 				ID:       "fullmetal-alchemist",
 				Title:    "Fullmetal Alchemist",
 				Category: "Precuela",
+				Type:     dto.RelationPrequel,
 			},
 		},
 		Genres: []string{
@@ -131,7 +142,7 @@ func MockAnimeInfoResponseFinished() dto.AnimeInfoResponse {
 		},
 		Status:      dto.Finalizado,
 		NextEpisode: "",
-		Episodes:    generateEpisodeNumbers(1, 64),
+		Episodes:    MockEpisodes("fullmetal-alchemist-brotherhood", 2012, generateEpisodeNumbers(1, 64)...),
 	}
 }
 
@@ -169,37 +180,94 @@ func MockEpisodeListResponse() []dto.EpisodeListResponse {
 	}
 }
 
+// MockOnAir retorna una lista de animes en emisión.
+func MockOnAir() []dto.AnimeRef {
+	return []dto.AnimeRef{
+		{ID: "one-piece-tv", Title: "One Piece", Type: dto.Anime},
+		{ID: "detective-conan", Title: "Detective Conan", Type: dto.Anime},
+		{ID: "one-punch-man-3", Title: "One Punch Man 3", Type: dto.Anime},
+	}
+}
+
+// MockHomeSnapshot retorna una instantánea de la página de inicio con todas sus secciones.
+func MockHomeSnapshot() dto.HomeSnapshot {
+	return dto.HomeSnapshot{
+		RecentAnime:    MockAnimeStructList(),
+		RecentEpisodes: MockEpisodeListResponse(),
+		OnAir:          MockOnAir(),
+	}
+}
+
+// MockGenres retorna un catálogo reducido de géneros.
+func MockGenres() []dto.Genre {
+	return []dto.Genre{
+		{Slug: "accion", Name: "Acción"},
+		{Slug: "artes-marciales", Name: "Artes Marciales"},
+		{Slug: "comedia", Name: "Comedia"},
+		{Slug: "shounen", Name: "Shounen"},
+	}
+}
+
 // MockLinkResponse retorna enlaces de reproducción para un episodio.
 func MockLinkResponse() dto.LinkResponse {
 	return dto.LinkResponse{
-		ID:      "one-piece-tv",
-		Title:   "One Piece",
-		Episode: 1090,
+		ID:          "one-piece-tv",
+		Title:       "One Piece",
+		Episode:     1090,
+		AnimeID:     130,
+		EpisodeID:   78012,
+		PrevEpisode: 1089,
+		NextEpisode: 1091,
 		Link: []dto.LinkSource{
 			{
-				Server: "StreamSB",
-				URL:    "https://streamsb.net/e/abc123def456",
-				Code:   "abc123def456",
+				Server:   "StreamSB",
+				URL:      "https://streamsb.net/e/abc123def456",
+				Code:     "abc123def456",
+				Language: dto.LanguageSUB,
 			},
 			{
-				Server: "Mega",
-				URL:    "https://mega.nz/file/xyz789uvw",
-				Code:   "xyz789uvw",
+				Server:   "Mega",
+				URL:      "https://mega.nz/file/xyz789uvw",
+				Code:     "xyz789uvw",
+				Language: dto.LanguageSUB,
 			},
 			{
-				Server: "Fembed",
-				URL:    "https://fembed.com/v/qrs456tuv",
-				Code:   "qrs456tuv",
+				Server:   "Fembed",
+				URL:      "https://fembed.com/v/qrs456tuv",
+				Code:     "qrs456tuv",
+				Language: dto.LanguageSUB,
 			},
 			{
-				Server: "Okru",
-				URL:    "https://ok.ru/video/123456789",
-				Code:   "123456789",
+				Server:   "Okru",
+				URL:      "https://ok.ru/video/123456789",
+				Code:     "123456789",
+				Language: dto.LanguageSUB,
 			},
 			{
-				Server: "Zippyshare",
-				URL:    "https://www72.zippyshare.com/v/abcd1234/file.html",
-				Code:   "abcd1234",
+				Server:   "Zippyshare",
+				URL:      "https://www72.zippyshare.com/v/abcd1234/file.html",
+				Code:     "abcd1234",
+				Language: dto.LanguageSUB,
+			},
+			{
+				Server:   "Okru",
+				URL:      "https://ok.ru/video/987654321",
+				Code:     "987654321",
+				Language: dto.LanguageLAT,
+			},
+		},
+		Downloads: []dto.DownloadSource{
+			{
+				Server:   "MEGA",
+				Format:   "MP4",
+				Language: dto.LanguageSUB,
+				URL:      "https://mega.nz/file/xyz789uvw",
+			},
+			{
+				Server:   "MEGA",
+				Format:   "MP4",
+				Language: dto.LanguageLAT,
+				URL:      "https://mega.nz/file/lat789uvw",
 			},
 		},
 	}
@@ -215,14 +283,16 @@ func MockLinkResponseMultipleEpisodes() []dto.LinkResponse {
 			Episode: 1089,
 			Link: []dto.LinkSource{
 				{
-					Server: "StreamSB",
-					URL:    "https://streamsb.net/e/prev123",
-					Code:   "prev123",
+					Server:   "StreamSB",
+					URL:      "https://streamsb.net/e/prev123",
+					Code:     "prev123",
+					Language: dto.LanguageSUB,
 				},
 				{
-					Server: "Mega",
-					URL:    "https://mega.nz/file/prev456",
-					Code:   "prev456",
+					Server:   "Mega",
+					URL:      "https://mega.nz/file/prev456",
+					Code:     "prev456",
+					Language: dto.LanguageSUB,
 				},
 			},
 		},
@@ -245,6 +315,21 @@ func MockAnimeResponseSinglePage() dto.AnimeResponse {
 	}
 }
 
+// MockEpisodes retorna los episodios indicados de un anime con su ruta y su miniatura.
+// Los IDs de episodio se derivan del ID numérico del anime y del número de episodio.
+func MockEpisodes(idAnime string, animeID int, numbers ...int) []dto.Episode {
+	episodes := make([]dto.Episode, len(numbers))
+	for i, number := range numbers {
+		episodes[i] = dto.Episode{
+			Number:       number,
+			ID:           animeID*10000 + number,
+			URL:          fmt.Sprintf("/ver/%s-%d", idAnime, number),
+			ThumbnailURL: fmt.Sprintf("https://cdn.animeflv.net/screenshots/%d/%d/th_3.jpg", animeID, number),
+		}
+	}
+	return episodes
+}
+
 // generateEpisodeNumbers genera una lista de números de episodios.
 func generateEpisodeNumbers(start, end int) []int {
 	episodes := make([]int, end-start+1)
@@ -0,0 +1,160 @@
+// Package mocks - mocks_ports.go
+// Este archivo contiene implementaciones de prueba de los puertos de la aplicación.
+// ScraperStub permite sustituir el scraper HTTP por funciones configurables y
+// CacheStub sustituye a Valkey por un mapa en memoria, de modo que los servicios
+// puedan probarse sin acceso a la red ni a un servidor de caché.
+package mocks
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+)
+
+// ScraperStub implementa ports.ScraperPort delegando en funciones configurables.
+// Si una función no está definida, el método retorna los datos mock equivalentes.
+type ScraperStub struct {
+	SearchAnimeFn func(ctx context.Context, anime string, page string) (dto.AnimeResponse, error)
+	SearchFn      func(ctx context.Context) (dto.AnimeResponse, error)
+	BrowseFn      func(ctx context.Context, filter dto.BrowseFilter, page string) (dto.AnimeResponse, error)
+	GenresFn      func(ctx context.Context) ([]dto.Genre, error)
+	AnimeInfoFn   func(ctx context.Context, idAnime string) (dto.AnimeInfoResponse, error)
+	LinksFn       func(ctx context.Context, idAnime string, episode uint) (dto.LinkResponse, error)
+	HomeFn        func(ctx context.Context) (dto.HomeSnapshot, error)
+}
+
+// SearchAnime retorna el resultado de SearchAnimeFn o MockAnimeResponse.
+func (s *ScraperStub) SearchAnime(ctx context.Context, anime string, page string) (dto.AnimeResponse, error) {
+	if s.SearchAnimeFn != nil {
+		return s.SearchAnimeFn(ctx, anime, page)
+	}
+	return MockAnimeResponse(), nil
+}
+
+// Search retorna el resultado de SearchFn o MockAnimeResponse.
+func (s *ScraperStub) Search(ctx context.Context) (dto.AnimeResponse, error) {
+	if s.SearchFn != nil {
+		return s.SearchFn(ctx)
+	}
+	return MockAnimeResponse(), nil
+}
+
+// Browse retorna el resultado de BrowseFn o MockAnimeResponse.
+func (s *ScraperStub) Browse(ctx context.Context, filter dto.BrowseFilter, page string) (dto.AnimeResponse, error) {
+	if s.BrowseFn != nil {
+		return s.BrowseFn(ctx, filter, page)
+	}
+	return MockAnimeResponse(), nil
+}
+
+// Genres retorna el resultado de GenresFn o MockGenres.
+func (s *ScraperStub) Genres(ctx context.Context) ([]dto.Genre, error) {
+	if s.GenresFn != nil {
+		return s.GenresFn(ctx)
+	}
+	return MockGenres(), nil
+}
+
+// AnimeInfo retorna el resultado de AnimeInfoFn o MockAnimeInfoResponse con el ID solicitado.
+func (s *ScraperStub) AnimeInfo(ctx context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+	if s.AnimeInfoFn != nil {
+		return s.AnimeInfoFn(ctx, idAnime)
+	}
+	result := MockAnimeInfoResponse()
+	result.ID = idAnime
+	return result, nil
+}
+
+// Links retorna el resultado de LinksFn o MockLinkResponse con el ID y episodio solicitados.
+func (s *ScraperStub) Links(ctx context.Context, idAnime string, episode uint) (dto.LinkResponse, error) {
+	if s.LinksFn != nil {
+		return s.LinksFn(ctx, idAnime, episode)
+	}
+	result := MockLinkResponse()
+	result.ID = idAnime
+	result.Episode = episode
+	return result, nil
+}
+
+// Home retorna el resultado de HomeFn o MockHomeSnapshot.
+func (s *ScraperStub) Home(ctx context.Context) (dto.HomeSnapshot, error) {
+	if s.HomeFn != nil {
+		return s.HomeFn(ctx)
+	}
+	return MockHomeSnapshot(), nil
+}
+
+// CacheStub implementa ports.CachePort en memoria, serializando los valores a JSON
+// igual que el adaptador de Valkey. Registra el número de lecturas y escrituras.
+type CacheStub struct {
+	mu     sync.Mutex
+	data   map[string][]byte
+	Gets   int
+	Sets   int
+	SetErr error                    // Si no es nil, Set falla con este error sin guardar el valor
+	TTLs   map[string]time.Duration // TTL recibida por SetWithTTL para cada clave
+}
+
+// NewCacheStub crea un caché en memoria vacío.
+func NewCacheStub() *CacheStub {
+	return &CacheStub{data: make(map[string][]byte), TTLs: make(map[string]time.Duration)}
+}
+
+// Exists verifica si la clave existe en memoria.
+func (c *CacheStub) Exists(_ context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.data[key]
+	return ok, nil
+}
+
+// Get deserializa el valor guardado bajo key en dest o retorna errs.ErrCacheMiss.
+func (c *CacheStub) Get(_ context.Context, key string, dest interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Gets++
+	data, ok := c.data[key]
+	if !ok {
+		return errs.ErrCacheMiss
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// Set serializa y guarda value bajo key.
+func (c *CacheStub) Set(_ context.Context, key string, value interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Sets++
+	if c.SetErr != nil {
+		return c.SetErr
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.data[key] = data
+	return nil
+}
+
+// SetWithTTL guarda value bajo key y registra la TTL solicitada en TTLs.
+func (c *CacheStub) SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if err := c.Set(ctx, key, value); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.TTLs[key] = ttl
+	return nil
+}
+
+// Delete elimina la clave de memoria.
+func (c *CacheStub) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, key)
+	return nil
+}
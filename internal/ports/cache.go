@@ -6,7 +6,10 @@
 // afectar la lógica de negocio de la aplicación.
 package ports
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // CachePort define el contrato que debe cumplir cualquier implementación de caché.
 // Proporciona operaciones básicas de almacenamiento, recuperación, eliminación y verificación de existencia.
@@ -23,3 +26,15 @@ type CachePort interface {
 	// Delete elimina una clave del caché.
 	Delete(ctx context.Context, key string) error
 }
+
+// CacheTTLPort es una capacidad opcional de CachePort para guardar valores con una TTL propia.
+// Los servicios la usan para datos que cambian poco (como el catálogo de géneros);
+// si el caché no la implementa, se usa Set con la TTL por defecto del caché.
+// Si el caché la implementa, las operaciones con copia de respaldo (listado completo, catálogo
+// sin filtros, géneros, información de anime, enlaces y página de inicio) guardan además cada valor bajo "stale-{clave}" con una TTL
+// de 7 días: esas operaciones escriben dos veces, y cada clave consultada en la última semana
+// ocupa espacio aunque su entrada normal ya haya expirado.
+type CacheTTLPort interface {
+	// SetWithTTL almacena un valor en el caché con la TTL indicada.
+	SetWithTTL(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+}
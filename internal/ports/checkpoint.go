@@ -0,0 +1,25 @@
+// Package ports define las interfaces (puertos) que establecen contratos entre
+// las diferentes capas de la aplicación siguiendo la arquitectura hexagonal.
+//
+// checkpoint.go define CheckpointStore, la interfaz donde el rastreador del catálogo
+// persiste su progreso. Permite guardar el checkpoint en un archivo, en Valkey o en
+// cualquier otro almacenamiento sin afectar la lógica del rastreo.
+package ports
+
+import (
+	"context"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+)
+
+// CheckpointStore define el contrato para persistir el progreso de un rastreo del catálogo.
+type CheckpointStore interface {
+	// Load recupera el checkpoint guardado. found es false si no existe ninguno.
+	Load(ctx context.Context) (checkpoint dto.CrawlCheckpoint, found bool, err error)
+
+	// Save guarda el checkpoint, reemplazando el anterior.
+	Save(ctx context.Context, checkpoint dto.CrawlCheckpoint) error
+
+	// Clear elimina el checkpoint guardado; no falla si no existe.
+	Clear(ctx context.Context) error
+}
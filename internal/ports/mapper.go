@@ -21,5 +21,5 @@ type Mapperport interface {
 	// ToAnimeinfo transforma datos completos de anime en un DTO AnimeInfoResponse.
 	// Combina información básica con datos adicionales como géneros, episodios,
 	// animes relacionados y estado de emisión en una estructura unificada.
-	ToAnimeinfo(id string, title string, sipnopsis string, tipo string, puctuation float64, image string, animerelated []dto.AnimeRelated, generos []string, estado string, episodes []int, nextepisode string) dto.AnimeInfoResponse
+	ToAnimeinfo(id string, title string, sipnopsis string, tipo string, puctuation float64, image string, animerelated []dto.AnimeRelated, generos []string, estado string, episodes []dto.Episode, nextepisode string) dto.AnimeInfoResponse
 }
@@ -5,7 +5,9 @@
 // cualquier scraper de sitios de anime. Esto permite cambiar la fuente de datos
 // (por ejemplo, de AnimeFlv a otro sitio) sin afectar la lógica de negocio.
 // Define operaciones como búsqueda, información detallada, obtención de enlaces
-// de reproducción, y listado de contenido reciente.
+// de reproducción, y listado de contenido reciente. También define ScraperMiddleware,
+// el decorador que permite añadir comportamiento (caché, logging, métricas, auditoría)
+// alrededor de cualquier ScraperPort.
 package ports
 
 import (
@@ -18,8 +20,27 @@ import (
 type ScraperPort interface {
 	SearchAnime(ctx context.Context, anime string, page string) (dto.AnimeResponse, error)
 	Search(ctx context.Context) (dto.AnimeResponse, error)
+	Browse(ctx context.Context, filter dto.BrowseFilter, page string) (dto.AnimeResponse, error)
+	Genres(ctx context.Context) ([]dto.Genre, error)
+	Home(ctx context.Context) (dto.HomeSnapshot, error)
 	AnimeInfo(ctx context.Context, idAnime string) (dto.AnimeInfoResponse, error)
 	Links(ctx context.Context, idAnime string, episode uint) (dto.LinkResponse, error)
-	RecentAnime(ctx context.Context) ([]dto.AnimeStruct, error)
-	RecentEpisode(ctx context.Context) ([]dto.EpisodeListResponse, error)
+}
+
+// ScraperMiddleware decora un ScraperPort y retorna otro que añade comportamiento
+// antes o después de delegar en el original, sin modificar su implementación.
+type ScraperMiddleware func(ScraperPort) ScraperPort
+
+// CircuitStatePort es una capacidad opcional de ScraperPort para exponer el estado
+// de su circuit breaker. El scraper HTTP de AnimeFlv la implementa.
+type CircuitStatePort interface {
+	// CircuitState retorna el estado actual del circuit breaker.
+	CircuitState() dto.CircuitState
+}
+
+// RateLimitPort es una capacidad opcional de ScraperPort para exponer el estado de sus
+// límites de peticiones. El scraper HTTP de AnimeFlv la implementa.
+type RateLimitPort interface {
+	// RateLimits retorna el estado del límite de cada clase de operación.
+	RateLimits() []dto.RateLimitState
 }
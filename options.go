@@ -0,0 +1,76 @@
+// Package anime - options.go
+// Este archivo define las opciones funcionales aceptadas por New para construir
+// la fachada AnimeFlv con dependencias inyectadas (configuración, cliente HTTP,
+// caché, logger y scraper) sin depender del singleton global de configuración.
+package anime
+
+import (
+	"net/http"
+
+	"github.com/dst3v3n/api-anime/internal/config"
+	"github.com/dst3v3n/api-anime/internal/ports"
+	"github.com/rs/zerolog"
+)
+
+// Option configura una dependencia de la fachada AnimeFlv durante su construcción.
+type Option func(*options)
+
+// options contiene las dependencias recolectadas a partir de las Option recibidas por New.
+type options struct {
+	config      *config.Config
+	httpClient  *http.Client
+	cache       ports.CachePort
+	logger      *zerolog.Logger
+	scraper     ports.ScraperPort
+	middlewares []ports.ScraperMiddleware
+}
+
+// WithConfig establece la configuración de la librería.
+// Si no se proporciona, New usa config.NewConfigWithDefaults().
+func WithConfig(cfg *config.Config) Option {
+	return func(o *options) {
+		o.config = cfg
+	}
+}
+
+// WithHTTPClient establece el cliente HTTP usado por el scraper por defecto.
+// Se ignora si también se inyecta un scraper con WithScraper.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithCache inyecta una implementación de caché (ver types.CachePort).
+// Si el caché está habilitado y no se proporciona, New abre una conexión a Valkey.
+// El ciclo de vida de un caché inyectado pertenece al consumidor.
+func WithCache(cache ports.CachePort) Option {
+	return func(o *options) {
+		o.cache = cache
+	}
+}
+
+// WithLogger establece el logger de Zerolog usado por la librería.
+// Si no se proporciona, se construye a partir de la configuración.
+func WithLogger(logger zerolog.Logger) Option {
+	return func(o *options) {
+		o.logger = &logger
+	}
+}
+
+// WithScraper inyecta una implementación de scraper (ver types.ScraperPort).
+// Útil para pruebas o para apuntar a una fuente de datos distinta.
+func WithScraper(scraper ports.ScraperPort) Option {
+	return func(o *options) {
+		o.scraper = scraper
+	}
+}
+
+// WithMiddleware añade middlewares (ver types.ScraperMiddleware) alrededor del scraper.
+// Se ubican entre los servicios y los middlewares integrados de caché y logging, en el
+// orden recibido: el primero es el más externo. Puede invocarse varias veces.
+func WithMiddleware(middlewares ...ports.ScraperMiddleware) Option {
+	return func(o *options) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
@@ -0,0 +1,145 @@
+// Package anime_test contiene tests unitarios de la fachada pública AnimeFlv.
+// Este archivo (anime_test.go) verifica la construcción de la fachada con New y sus
+// opciones, y que NewAnimeFlv conserva su comportamiento, sin acceso a la red ni a Valkey.
+package anime_test
+
+import (
+	"context"
+	"testing"
+
+	anime "github.com/dst3v3n/api-anime"
+	"github.com/dst3v3n/api-anime/internal/config"
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/mocks"
+)
+
+// unreachableValkey retorna una configuración con el caché habilitado apuntando a un puerto
+// donde no escucha ningún servidor.
+func unreachableValkey() *config.Config {
+	return config.NewConfigWithDefaults().
+		WithCache(true).
+		WithCacheHost("127.0.0.1").
+		WithCachePort(1)
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        []anime.Option
+		wantError   bool
+		description string
+	}{
+		{
+			name:        "valores por defecto",
+			opts:        nil,
+			wantError:   false,
+			description: "sin opciones debe construirse con la configuración por defecto",
+		},
+		{
+			name:        "configuración inválida",
+			opts:        []anime.Option{anime.WithConfig(config.NewConfigWithDefaults().WithCachePort(0))},
+			wantError:   true,
+			description: "debe retornar el error de validación de la configuración",
+		},
+		{
+			name:        "Valkey inaccesible",
+			opts:        []anime.Option{anime.WithConfig(unreachableValkey())},
+			wantError:   true,
+			description: "debe retornar un error en lugar de entrar en pánico",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service, err := anime.New(tc.opts...)
+			if (err != nil) != tc.wantError {
+				t.Fatalf("New() error = %v, wantError %v (%s)", err, tc.wantError, tc.description)
+			}
+			if (service == nil) != tc.wantError {
+				t.Errorf("New() service = %v, want nil solo con error (%s)", service, tc.description)
+			}
+			if service != nil {
+				service.Close(context.Background())
+			}
+		})
+	}
+}
+
+func TestNewWithInjectedDependencies(t *testing.T) {
+	var searches int
+	scraper := &mocks.ScraperStub{
+		SearchAnimeFn: func(_ context.Context, _ string, _ string) (dto.AnimeResponse, error) {
+			searches++
+			return mocks.MockAnimeResponse(), nil
+		},
+	}
+	cache := mocks.NewCacheStub()
+
+	// La configuración apunta a un Valkey inaccesible: New solo tiene éxito si usa el caché inyectado.
+	service, err := anime.New(
+		anime.WithConfig(unreachableValkey()),
+		anime.WithScraper(scraper),
+		anime.WithCache(cache),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer service.Close(context.Background())
+
+	for range 2 {
+		if _, err := service.SearchAnime(context.Background(), "naruto", 1); err != nil {
+			t.Fatalf("SearchAnime() error = %v", err)
+		}
+	}
+
+	if searches != 1 {
+		t.Errorf("búsquedas en el scraper inyectado = %d, want 1", searches)
+	}
+	if cache.Sets == 0 || cache.Gets == 0 {
+		t.Errorf("uso del caché inyectado: %d lecturas y %d escrituras, want ambas > 0", cache.Gets, cache.Sets)
+	}
+}
+
+func TestNewAnimeFlv(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      *config.Config
+		wantPanic   bool
+		description string
+	}{
+		{
+			name:        "singleton con valores por defecto",
+			config:      config.NewConfigWithDefaults(),
+			wantPanic:   false,
+			description: "debe construir la fachada a partir del singleton de configuración",
+		},
+		{
+			name:        "Valkey inaccesible",
+			config:      unreachableValkey(),
+			wantPanic:   true,
+			description: "como antes, entra en pánico si no puede conectar con Valkey",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config.ResetConfig()
+			t.Cleanup(config.ResetConfig)
+			if err := config.InitConfig(tc.config); err != nil {
+				t.Fatalf("InitConfig() error = %v", err)
+			}
+
+			defer func() {
+				if recovered := recover(); (recovered != nil) != tc.wantPanic {
+					t.Errorf("NewAnimeFlv() panic = %v, wantPanic %v (%s)", recovered, tc.wantPanic, tc.description)
+				}
+			}()
+
+			service := anime.NewAnimeFlv()
+			if service == nil {
+				t.Fatal("NewAnimeFlv() = nil")
+			}
+			service.Close(context.Background())
+		})
+	}
+}
@@ -0,0 +1,618 @@
+// Package animeflv contiene tests unitarios para el cliente HTTP del scraper de AnimeFlv.
+// Este archivo (client_test.go) verifica la URL base configurable, la consulta de /browse,
+// el failover entre mirrors, los reintentos, el circuit breaker, la detección de verificaciones
+// anti-bot, los límites de peticiones adaptativos y el límite global contra servidores HTTP
+// locales, sin acceder al sitio real.
+package animeflv
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dst3v3n/api-anime/internal/adapters/scrapers/animeflv"
+	"github.com/dst3v3n/api-anime/internal/config"
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+	"github.com/dst3v3n/api-anime/internal/ports"
+)
+
+// countingServer crea un servidor de pruebas que responde con status y body y cuenta sus peticiones.
+func countingServer(t *testing.T, status int, body []byte, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(status)
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClientBrowseQuery(t *testing.T) {
+	testCases := []struct {
+		name        string
+		filter      dto.BrowseFilter
+		page        string
+		want        url.Values
+		description string
+	}{
+		{
+			name: "películas de acción de 2024 por calificación",
+			filter: dto.BrowseFilter{
+				Genres: []string{"accion"},
+				Years:  []int{2024},
+				Types:  []dto.BrowseType{dto.BrowseMovie},
+				Order:  dto.OrderRating,
+			},
+			page: "2",
+			want: url.Values{
+				"genre[]": {"accion"},
+				"year[]":  {"2024"},
+				"type[]":  {"movie"},
+				"order":   {"rating"},
+				"page":    {"2"},
+			},
+			description: "cada filtro debe enviarse con el parámetro que espera /browse",
+		},
+		{
+			name: "varios valores por filtro",
+			filter: dto.BrowseFilter{
+				Genres: []string{"accion", "drama"},
+				Years:  []int{2023, 2024},
+				Status: []dto.BrowseStatus{dto.BrowseEmision, dto.BrowseFinalizado},
+			},
+			page: "1",
+			want: url.Values{
+				"genre[]":  {"accion", "drama"},
+				"year[]":   {"2023", "2024"},
+				"status[]": {"1", "2"},
+				"page":     {"1"},
+			},
+			description: "los filtros con varios valores deben repetir el parámetro en orden",
+		},
+		{
+			name:        "sin filtros ni página",
+			filter:      dto.BrowseFilter{},
+			page:        "",
+			want:        url.Values{"page": {"1"}},
+			description: "sin filtros solo debe enviarse la primera página",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotPath string
+			var gotQuery url.Values
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath, gotQuery = r.URL.Path, r.URL.Query()
+				w.Write(searchAnimeHTML)
+			}))
+			t.Cleanup(server.Close)
+			client := animeflv.NewClientWithConfig(nil, config.NewConfigWithDefaults().WithBaseURL(server.URL))
+
+			if _, err := client.Browse(context.Background(), tc.filter, tc.page); err != nil {
+				t.Fatalf("Browse() error = %v", err)
+			}
+			if gotPath != "/browse" {
+				t.Errorf("ruta = %q, want /browse", gotPath)
+			}
+			if !reflect.DeepEqual(gotQuery, tc.want) {
+				t.Errorf("consulta = %v, want %v (%s)", gotQuery, tc.want, tc.description)
+			}
+		})
+	}
+}
+
+func TestClientMirrorFailover(t *testing.T) {
+	testCases := []struct {
+		name            string
+		primaryStatus   int
+		primaryDown     bool
+		wantError       error
+		wantPrimaryHits int32
+		wantMirrorHits  int32
+		description     string
+	}{
+		{
+			name:            "5xx en la URL base",
+			primaryStatus:   http.StatusServiceUnavailable,
+			wantPrimaryHits: 1,
+			wantMirrorHits:  2,
+			description:     "debe pasar al mirror y omitir la URL base durante el enfriamiento",
+		},
+		{
+			name:            "URL base inaccesible",
+			primaryDown:     true,
+			wantPrimaryHits: 0,
+			wantMirrorHits:  2,
+			description:     "un error de conexión debe pasar al mirror",
+		},
+		{
+			name:            "404 en la URL base",
+			primaryStatus:   http.StatusNotFound,
+			wantError:       errs.ErrNotFound,
+			wantPrimaryHits: 2,
+			wantMirrorHits:  0,
+			description:     "un 404 no indica un host caído y no debe probar otros mirrors",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var primaryHits, mirrorHits atomic.Int32
+			primary := countingServer(t, tc.primaryStatus, nil, &primaryHits)
+			mirror := countingServer(t, http.StatusOK, animeInfoHTML, &mirrorHits)
+			if tc.primaryDown {
+				primary.Close()
+			}
+
+			cfg := config.NewConfigWithDefaults().
+				WithBaseURL(primary.URL).
+				WithMirrors(mirror.URL)
+			client := animeflv.NewClientWithConfig(nil, cfg)
+
+			for range 2 {
+				result, err := client.AnimeInfo(context.Background(), "naruto-shippuden-hd")
+				if !errors.Is(err, tc.wantError) {
+					t.Fatalf("AnimeInfo() error = %v, want %v", err, tc.wantError)
+				}
+				if tc.wantError == nil && result.Title == "" {
+					t.Error("AnimeInfo() retornó un título vacío")
+				}
+			}
+
+			if got := primaryHits.Load(); got != tc.wantPrimaryHits {
+				t.Errorf("peticiones a la URL base = %d, want %d (%s)", got, tc.wantPrimaryHits, tc.description)
+			}
+			if got := mirrorHits.Load(); got != tc.wantMirrorHits {
+				t.Errorf("peticiones al mirror = %d, want %d (%s)", got, tc.wantMirrorHits, tc.description)
+			}
+		})
+	}
+}
+
+// scriptedServer crea un servidor de pruebas que responde en orden con los códigos de statuses
+// (el último se repite), agregando Retry-After a las respuestas distintas de 200 si se indica.
+func scriptedServer(t *testing.T, statuses []int, retryAfter string, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		status := statuses[min(int(hits.Add(1)), len(statuses))-1]
+		if status != http.StatusOK {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write(animeInfoHTML)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClientRetry(t *testing.T) {
+	testCases := []struct {
+		name        string
+		statuses    []int
+		retryAfter  string
+		timeout     time.Duration
+		wantError   error
+		wantHits    int32
+		description string
+	}{
+		{
+			name:        "503 transitorio",
+			statuses:    []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK},
+			wantHits:    3,
+			description: "debe reintentar los 5xx hasta obtener respuesta",
+		},
+		{
+			name:        "intentos agotados",
+			statuses:    []int{http.StatusInternalServerError},
+			wantError:   &errs.UpstreamStatusError{},
+			wantHits:    3,
+			description: "debe rendirse tras el número máximo de intentos",
+		},
+		{
+			name:        "404 sin reintentos",
+			statuses:    []int{http.StatusNotFound},
+			wantError:   errs.ErrNotFound,
+			wantHits:    1,
+			description: "un 404 nunca se reintenta",
+		},
+		{
+			name:        "429 con Retry-After",
+			statuses:    []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:  "0",
+			wantHits:    2,
+			description: "debe reintentar un 429 respetando Retry-After",
+		},
+		{
+			name:        "Retry-After mayor que la espera máxima",
+			statuses:    []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter:  "120",
+			wantError:   errs.ErrRateLimited,
+			wantHits:    1,
+			description: "no debe bloquear más allá de la espera máxima configurada",
+		},
+		{
+			name:        "deadline del contexto",
+			statuses:    []int{http.StatusServiceUnavailable, http.StatusOK},
+			retryAfter:  "1",
+			timeout:     100 * time.Millisecond,
+			wantError:   &errs.UpstreamStatusError{},
+			wantHits:    1,
+			description: "no debe esperar un reintento que terminaría después del deadline",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var hits atomic.Int32
+			server := scriptedServer(t, tc.statuses, tc.retryAfter, &hits)
+
+			cfg := config.NewConfigWithDefaults().
+				WithBaseURL(server.URL).
+				WithRetry(3, time.Millisecond, 5*time.Second)
+			client := animeflv.NewClientWithConfig(nil, cfg)
+
+			ctx := context.Background()
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+
+			_, err := client.AnimeInfo(ctx, "naruto-shippuden-hd")
+			switch want := tc.wantError.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("AnimeInfo() error = %v, want nil", err)
+				}
+			case *errs.UpstreamStatusError:
+				if !errors.As(err, &want) {
+					t.Fatalf("AnimeInfo() error = %v, want *errs.UpstreamStatusError", err)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Fatalf("AnimeInfo() error = %v, want %v", err, want)
+				}
+			}
+
+			if got := hits.Load(); got != tc.wantHits {
+				t.Errorf("peticiones = %d, want %d (%s)", got, tc.wantHits, tc.description)
+			}
+		})
+	}
+}
+
+func TestClientCircuitBreaker(t *testing.T) {
+	var hits atomic.Int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write(animeInfoHTML)
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := config.NewConfigWithDefaults().
+		WithBaseURL(server.URL).
+		WithRetry(1, 0, 0).
+		WithCircuitBreaker(2, 50*time.Millisecond)
+	client := animeflv.NewClientWithConfig(nil, cfg)
+	circuit := client.(ports.CircuitStatePort)
+
+	testCases := []struct {
+		name        string
+		wait        time.Duration
+		healthy     bool
+		wantError   bool
+		wantOpenErr bool
+		wantState   dto.CircuitState
+		wantHits    int32
+		description string
+	}{
+		{
+			name:        "primer fallo",
+			wantError:   true,
+			wantState:   dto.CircuitClosed,
+			wantHits:    1,
+			description: "un fallo aislado no abre el circuito",
+		},
+		{
+			name:        "umbral alcanzado",
+			wantError:   true,
+			wantState:   dto.CircuitOpen,
+			wantHits:    2,
+			description: "el segundo fallo consecutivo abre el circuito",
+		},
+		{
+			name:        "circuito abierto",
+			wantError:   true,
+			wantOpenErr: true,
+			wantState:   dto.CircuitOpen,
+			wantHits:    2,
+			description: "con el circuito abierto no se contacta al sitio",
+		},
+		{
+			name:        "petición de prueba exitosa",
+			wait:        60 * time.Millisecond,
+			healthy:     true,
+			wantState:   dto.CircuitClosed,
+			wantHits:    3,
+			description: "tras el enfriamiento una petición exitosa cierra el circuito",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			time.Sleep(tc.wait)
+			healthy.Store(tc.healthy)
+
+			_, err := client.AnimeInfo(context.Background(), "naruto-shippuden-hd")
+			if (err != nil) != tc.wantError {
+				t.Fatalf("AnimeInfo() error = %v, wantError %v", err, tc.wantError)
+			}
+			if errors.Is(err, errs.ErrCircuitOpen) != tc.wantOpenErr {
+				t.Errorf("errors.Is(%v, ErrCircuitOpen) = %v, want %v", err, !tc.wantOpenErr, tc.wantOpenErr)
+			}
+			if got := circuit.CircuitState(); got != tc.wantState {
+				t.Errorf("CircuitState() = %v, want %v (%s)", got, tc.wantState, tc.description)
+			}
+			if got := hits.Load(); got != tc.wantHits {
+				t.Errorf("peticiones = %d, want %d", got, tc.wantHits)
+			}
+		})
+	}
+}
+
+func TestClientAdaptiveRateLimit(t *testing.T) {
+	var hits atomic.Int32
+	server := scriptedServer(t, []int{http.StatusTooManyRequests, http.StatusOK}, "", &hits)
+
+	limit := config.RateLimit{Rate: 100, Burst: 10}
+	cfg := config.NewConfigWithDefaults().
+		WithBaseURL(server.URL).
+		WithRetry(2, time.Millisecond, time.Second).
+		WithRateLimits(limit, limit, limit, limit).
+		WithRateRecoveryInterval(50 * time.Millisecond)
+	client := animeflv.NewClientWithConfig(nil, cfg)
+	limits := client.(ports.RateLimitPort)
+
+	if _, err := client.AnimeInfo(context.Background(), "naruto-shippuden-hd"); err != nil {
+		t.Fatalf("AnimeInfo() error = %v", err)
+	}
+
+	testCases := []struct {
+		name          string
+		wait          time.Duration
+		class         dto.RequestClass
+		wantMin       float64
+		wantMax       float64
+		wantThrottled bool
+		description   string
+	}{
+		{
+			name:          "ritmo reducido tras un 429",
+			class:         dto.RequestInfo,
+			wantMin:       50,
+			wantMax:       50,
+			wantThrottled: true,
+			description:   "un 429 debe reducir a la mitad el ritmo de la clase",
+		},
+		{
+			name:        "otras clases sin cambios",
+			class:       dto.RequestBrowse,
+			wantMin:     100,
+			wantMax:     100,
+			description: "un 429 solo reduce el ritmo de la clase que lo recibió",
+		},
+		{
+			name:          "recuperación gradual",
+			wait:          60 * time.Millisecond,
+			class:         dto.RequestInfo,
+			wantMin:       60,
+			wantMax:       90,
+			wantThrottled: true,
+			description:   "cada intervalo sin 429 recupera un 10% del ritmo configurado",
+		},
+		{
+			name:        "ritmo recuperado",
+			wait:        500 * time.Millisecond,
+			class:       dto.RequestInfo,
+			wantMin:     100,
+			wantMax:     100,
+			description: "el ritmo no debe superar el configurado",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			time.Sleep(tc.wait)
+
+			var state dto.RateLimitState
+			for _, s := range limits.RateLimits() {
+				if s.Class == tc.class {
+					state = s
+				}
+			}
+			if state.Rate < tc.wantMin || state.Rate > tc.wantMax {
+				t.Errorf("Rate de %s = %v, want entre %v y %v (%s)", tc.class, state.Rate, tc.wantMin, tc.wantMax, tc.description)
+			}
+			if state.Throttled != tc.wantThrottled {
+				t.Errorf("Throttled de %s = %v, want %v", tc.class, state.Throttled, tc.wantThrottled)
+			}
+			if state.ConfiguredRate != limit.Rate || state.Burst != limit.Burst {
+				t.Errorf("límite configurado de %s = %v/%d, want %v/%d", tc.class, state.ConfiguredRate, state.Burst, limit.Rate, limit.Burst)
+			}
+		})
+	}
+}
+
+func TestClientGlobalRateLimit(t *testing.T) {
+	classLimit := config.RateLimit{Rate: 1000, Burst: 100}
+
+	testCases := []struct {
+		name        string
+		global      config.RateLimit
+		wantMin     time.Duration
+		wantMax     time.Duration
+		description string
+	}{
+		{
+			name:        "tráfico mixto dentro del límite global",
+			global:      config.RateLimit{Rate: 20, Burst: 1},
+			wantMin:     300 * time.Millisecond,
+			wantMax:     5 * time.Second,
+			description: "8 peticiones de 4 clases a 20/s deben tardar al menos 350ms aunque cada clase permita 1000/s",
+		},
+		{
+			name:        "límite global holgado",
+			global:      config.RateLimit{Rate: 1000, Burst: 100},
+			wantMin:     0,
+			wantMax:     250 * time.Millisecond,
+			description: "sin un límite global estricto las peticiones no deben esperar",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var hits atomic.Int32
+			server := countingServer(t, http.StatusOK, animeInfoHTML, &hits)
+			cfg := config.NewConfigWithDefaults().
+				WithBaseURL(server.URL).
+				WithGlobalRateLimit(tc.global).
+				WithRateLimits(classLimit, classLimit, classLimit, classLimit)
+			client := animeflv.NewClientWithConfig(nil, cfg)
+			ctx := context.Background()
+
+			start := time.Now()
+			var wg sync.WaitGroup
+			for range 2 {
+				wg.Go(func() { client.SearchAnime(ctx, "naruto", "1") })
+				wg.Go(func() { client.AnimeInfo(ctx, "naruto") })
+				wg.Go(func() { client.Links(ctx, "naruto", 1) })
+				wg.Go(func() { client.Home(ctx) })
+			}
+			wg.Wait()
+			elapsed := time.Since(start)
+
+			if got := hits.Load(); got != 8 {
+				t.Fatalf("peticiones = %d, want 8", got)
+			}
+			if elapsed < tc.wantMin || elapsed > tc.wantMax {
+				t.Errorf("duración = %v, want entre %v y %v (%s)", elapsed, tc.wantMin, tc.wantMax, tc.description)
+			}
+		})
+	}
+}
+
+// challengeHTML es una página de verificación JavaScript de Cloudflare reducida.
+const challengeHTML = `<!DOCTYPE html><html lang="en-US"><head><title>Just a moment...</title></head>
+<body><div id="challenge-body-text">Enable JavaScript and cookies to continue</div>
+<script>(function(){window._cf_chl_opt={cvId: '3',cZone: "www3.animeflv.net",cType: 'managed'};}());</script>
+</body></html>`
+
+func TestClientChallenge(t *testing.T) {
+	testCases := []struct {
+		name         string
+		status       int
+		header       map[string]string
+		body         string
+		wantError    bool
+		wantProvider string
+		wantRayID    string
+		description  string
+	}{
+		{
+			name:         "desafío de Cloudflare con 403",
+			status:       http.StatusForbidden,
+			header:       map[string]string{"Server": "cloudflare", "CF-Ray": "8c1f2a3b4d5e6f70-MIA"},
+			body:         challengeHTML,
+			wantError:    true,
+			wantProvider: "cloudflare",
+			wantRayID:    "8c1f2a3b4d5e6f70-MIA",
+			description:  "un 403 con la página de verificación debe reportarse como ErrChallenged y no como 403",
+		},
+		{
+			name:         "desafío de Cloudflare con 200",
+			status:       http.StatusOK,
+			body:         challengeHTML,
+			wantError:    true,
+			wantProvider: "cloudflare",
+			description:  "la página de verificación servida con 200 no debe llegar al parser",
+		},
+		{
+			name:         "cabecera cf-mitigated",
+			status:       http.StatusForbidden,
+			header:       map[string]string{"CF-Mitigated": "challenge"},
+			wantError:    true,
+			wantProvider: "cloudflare",
+			description:  "la cabecera cf-mitigated basta para identificar la verificación",
+		},
+		{
+			name:         "página de DDoS-Guard",
+			status:       http.StatusForbidden,
+			body:         "<html><head><title>DDoS-Guard</title></head><body></body></html>",
+			wantError:    true,
+			wantProvider: "ddos-guard",
+			description:  "debe reconocer las verificaciones de DDoS-Guard",
+		},
+		{
+			name:        "página normal",
+			status:      http.StatusOK,
+			body:        string(animeInfoHTML),
+			wantError:   false,
+			description: "una página con el beacon de Cloudflare Insights no es una verificación",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for key, value := range tc.header {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			}))
+			t.Cleanup(server.Close)
+
+			cfg := config.NewConfigWithDefaults().
+				WithBaseURL(server.URL).
+				WithRetry(1, 0, 0)
+			client := animeflv.NewClientWithConfig(nil, cfg)
+
+			result, err := client.AnimeInfo(context.Background(), "naruto-shippuden-hd")
+			if errors.Is(err, errs.ErrChallenged) != tc.wantError {
+				t.Fatalf("errors.Is(%v, ErrChallenged) = %v, want %v (%s)", err, !tc.wantError, tc.wantError, tc.description)
+			}
+			if !tc.wantError {
+				if err != nil || result.Title == "" {
+					t.Errorf("AnimeInfo() = %q, %v; want la ficha del anime", result.Title, err)
+				}
+				return
+			}
+
+			var challenge *errs.ChallengeError
+			if !errors.As(err, &challenge) {
+				t.Fatalf("AnimeInfo() error = %v, want *errs.ChallengeError", err)
+			}
+			if challenge.Code != tc.status || challenge.Provider != tc.wantProvider || challenge.RayID != tc.wantRayID {
+				t.Errorf("ChallengeError = %+v, want Code %d, Provider %q, RayID %q", challenge, tc.status, tc.wantProvider, tc.wantRayID)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Package animeflv contiene tests unitarios para los servicios de dominio de AnimeFlv.
+// Este archivo (crawler_test.go) verifica el rastreo completo del catálogo con un
+// checkpoint en archivo: recorrido de páginas, fallos por anime y reanudación.
+package animeflv
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/dst3v3n/api-anime/internal/adapters/checkpoint"
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+	"github.com/dst3v3n/api-anime/internal/mocks"
+)
+
+func TestCrawlResume(t *testing.T) {
+	pages := map[string][]string{
+		"1": {"naruto", "bleach"},
+		"2": {"no-existe", "haikyuu"},
+		"3": {"haikyuu", "one-piece-tv"},
+	}
+
+	browsed := []string{}
+	scraper := &mocks.ScraperStub{
+		BrowseFn: func(_ context.Context, _ dto.BrowseFilter, page string) (dto.AnimeResponse, error) {
+			browsed = append(browsed, page)
+			result := dto.AnimeResponse{TotalPages: 3}
+			for _, id := range pages[page] {
+				result.Animes = append(result.Animes, dto.AnimeStruct{ID: id, Title: id})
+			}
+			return result, nil
+		},
+		AnimeInfoFn: func(_ context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+			if idAnime == "no-existe" {
+				return dto.AnimeInfoResponse{}, &errs.UpstreamStatusError{Code: 404, URL: idAnime}
+			}
+			result := mocks.MockAnimeInfoResponse()
+			result.ID = idAnime
+			return result, nil
+		},
+	}
+	service := newTestService(t, scraper)
+
+	path := filepath.Join(t.TempDir(), "crawl.json")
+	store := checkpoint.NewFile(path)
+	errCrash := errors.New("caída simulada")
+
+	testCases := []struct {
+		name        string
+		failAfter   int
+		wantErr     error
+		wantBrowsed []string
+		wantIDs     []string
+		description string
+	}{
+		{
+			name:        "rastreo interrumpido",
+			failAfter:   2,
+			wantErr:     errCrash,
+			wantBrowsed: []string{"1", "2", "3"},
+			wantIDs:     []string{"naruto", "bleach"},
+			description: "un error de OnAnime detiene el rastreo sin avanzar el checkpoint",
+		},
+		{
+			name:        "rastreo reanudado",
+			wantBrowsed: []string{"1", "2", "3"},
+			wantIDs:     []string{"haikyuu", "one-piece-tv"},
+			description: "debe continuar desde el checkpoint sin volver a recorrer las páginas",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var delivered []string
+			var progress []dto.CrawlProgress
+			err := service.Crawl(context.Background(), store, dto.CrawlOptions{
+				OnAnime: func(info dto.AnimeInfoResponse) error {
+					if tc.failAfter > 0 && len(delivered) == tc.failAfter {
+						return errCrash
+					}
+					delivered = append(delivered, info.ID)
+					return nil
+				},
+				Progress: func(p dto.CrawlProgress) {
+					progress = append(progress, p)
+				},
+			})
+
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Crawl() error = %v, want %v", err, tc.wantErr)
+			}
+			if !slices.Equal(browsed, tc.wantBrowsed) {
+				t.Errorf("páginas recorridas = %v, want %v", browsed, tc.wantBrowsed)
+			}
+			if !slices.Equal(delivered, tc.wantIDs) {
+				t.Errorf("animes entregados = %v, want %v", delivered, tc.wantIDs)
+			}
+			if len(progress) == 0 {
+				t.Error("Progress no fue invocado")
+			}
+
+			_, statErr := os.Stat(path)
+			if tc.wantErr != nil && statErr != nil {
+				t.Errorf("el checkpoint debe persistir tras la interrupción: %v", statErr)
+			}
+			if tc.wantErr == nil {
+				if !errors.Is(statErr, os.ErrNotExist) {
+					t.Errorf("el checkpoint debe eliminarse al terminar: %v", statErr)
+				}
+				last := progress[len(progress)-1]
+				if last.Phase != dto.CrawlDone || last.Total != 5 {
+					t.Errorf("último progreso = %+v, want fase done con 5 animes", last)
+				}
+			}
+		})
+	}
+}
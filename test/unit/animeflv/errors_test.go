@@ -0,0 +1,127 @@
+// Package animeflv contiene tests unitarios para la taxonomía de errores tipados.
+// Este archivo (errors_test.go) verifica que los errores del scraper y del parser
+// puedan distinguirse con errors.Is y errors.As.
+package animeflv
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/dst3v3n/api-anime/internal/adapters/scrapers/animeflv"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+)
+
+func TestUpstreamStatusErrorIs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        int
+		target      error
+		want        bool
+		description string
+	}{
+		{
+			name:        "404 es ErrNotFound",
+			code:        http.StatusNotFound,
+			target:      errs.ErrNotFound,
+			want:        true,
+			description: "un 404 del sitio debe reconocerse como recurso inexistente",
+		},
+		{
+			name:        "429 es ErrRateLimited",
+			code:        http.StatusTooManyRequests,
+			target:      errs.ErrRateLimited,
+			want:        true,
+			description: "un 429 del sitio debe reconocerse como límite de peticiones",
+		},
+		{
+			name:        "500 no es ErrNotFound",
+			code:        http.StatusInternalServerError,
+			target:      errs.ErrNotFound,
+			want:        false,
+			description: "un error del servidor no debe confundirse con un recurso inexistente",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var err error = &errs.UpstreamStatusError{Code: tc.code, URL: "https://www3.animeflv.net/anime/x"}
+
+			if got := errors.Is(err, tc.target); got != tc.want {
+				t.Errorf("errors.Is(%v, %v) = %v, want %v", err, tc.target, got, tc.want)
+			}
+
+			var statusErr *errs.UpstreamStatusError
+			if !errors.As(err, &statusErr) || statusErr.Code != tc.code {
+				t.Errorf("errors.As no recuperó el código %d", tc.code)
+			}
+		})
+	}
+}
+
+func TestParseErrorTyped(t *testing.T) {
+	testCases := []struct {
+		name        string
+		parse       func(parser *animeflv.Parser) error
+		wantPage    string
+		wantField   string
+		description string
+	}{
+		{
+			name: "búsqueda sin animes",
+			parse: func(parser *animeflv.Parser) error {
+				_, err := parser.ParseAnimeWithPagination(bytes.NewReader(searchAnimeFatalHTML))
+				return err
+			},
+			wantPage:    errs.PageBrowse,
+			wantField:   "animes",
+			description: "debe reportar la página de búsqueda y el campo animes",
+		},
+		{
+			name: "información de anime sin título",
+			parse: func(parser *animeflv.Parser) error {
+				_, err := parser.ParseAnimeInfo(bytes.NewReader(animeInfoFatalHTML), "naruto-shippuden-hd")
+				return err
+			},
+			wantPage:    errs.PageAnimeInfo,
+			wantField:   "title",
+			description: "debe reportar la ficha del anime y el campo title",
+		},
+		{
+			name: "episodio sin videos",
+			parse: func(parser *animeflv.Parser) error {
+				_, err := parser.ParseLinks(bytes.NewReader(episodeLinksFatalHTML), "naruto-shippuden-hd", 220)
+				return err
+			},
+			wantPage:    errs.PageEpisode,
+			wantField:   "videos",
+			description: "debe reportar la página del episodio y el campo videos",
+		},
+		{
+			name: "inicio sin animes recientes",
+			parse: func(parser *animeflv.Parser) error {
+				_, err := parser.ParseAnime(bytes.NewReader(homeAnimeflvFatalHTML))
+				return err
+			},
+			wantPage:    errs.PageHome,
+			wantField:   "animes",
+			description: "debe reportar la página de inicio aunque el error esté envuelto",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.parse(animeflv.NewParser())
+
+			var parseErr *errs.ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("error = %v, want *errs.ParseError", err)
+			}
+
+			if parseErr.Page != tc.wantPage || parseErr.Field != tc.wantField {
+				t.Errorf("ParseError = {%s %s}, want {%s %s}", parseErr.Page, parseErr.Field, tc.wantPage, tc.wantField)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Package animeflv contiene tests unitarios para los servicios de dominio de AnimeFlv.
+// Este archivo (franchise_test.go) verifica la resolución de franquicias: orden de
+// visualización, detección de ciclos, límite de profundidad y animes no resueltos.
+package animeflv
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+	"github.com/dst3v3n/api-anime/internal/mocks"
+)
+
+// related crea una relación de un anime con el tipo indicado.
+func related(id string, relationType dto.RelationType) dto.AnimeRelated {
+	return dto.AnimeRelated{ID: id, Title: id, Type: relationType}
+}
+
+// sequelChain crea una cadena de n animes donde cada uno es secuela del anterior.
+func sequelChain(n int) map[string][]dto.AnimeRelated {
+	graph := make(map[string][]dto.AnimeRelated, n)
+	for i := 1; i < n; i++ {
+		graph[fmt.Sprintf("parte-%d", i)] = []dto.AnimeRelated{related(fmt.Sprintf("parte-%d", i+1), dto.RelationSequel)}
+	}
+	graph[fmt.Sprintf("parte-%d", n)] = nil
+	return graph
+}
+
+func TestFranchise(t *testing.T) {
+	testCases := []struct {
+		name           string
+		graph          map[string][]dto.AnimeRelated
+		root           string
+		wantOrder      []string
+		wantUnresolved []string
+		wantTruncated  bool
+		description    string
+	}{
+		{
+			name: "orden de visualización",
+			graph: map[string][]dto.AnimeRelated{
+				"naruto-shippuden": {
+					related("naruto", dto.RelationPrequel),
+					related("shippuden-pelicula", dto.RelationSideStory),
+					related("boruto", dto.RelationSequel),
+				},
+				"naruto": {
+					related("naruto-shippuden", dto.RelationSequel),
+					related("naruto-pelicula", dto.RelationSideStory),
+				},
+				"shippuden-pelicula": {related("naruto-shippuden", dto.RelationParentStory)},
+				"boruto":             {related("naruto-shippuden", dto.RelationPrequel)},
+				"naruto-pelicula":    {related("naruto", dto.RelationParentStory)},
+			},
+			root:        "naruto-shippuden",
+			wantOrder:   []string{"naruto", "naruto-pelicula", "naruto-shippuden", "shippuden-pelicula", "boruto"},
+			description: "las precuelas van primero y cada historia paralela justo después de su serie",
+		},
+		{
+			name: "relaciones en ciclo",
+			graph: map[string][]dto.AnimeRelated{
+				"a": {related("b", dto.RelationSequel)},
+				"b": {related("a", dto.RelationSequel)},
+			},
+			root:        "a",
+			wantOrder:   []string{"a", "b"},
+			description: "un ciclo no debe bloquear el recorrido ni el ordenamiento",
+		},
+		{
+			name:          "límite de profundidad",
+			graph:         sequelChain(8),
+			root:          "parte-1",
+			wantOrder:     []string{"parte-1", "parte-2", "parte-3", "parte-4", "parte-5"},
+			wantTruncated: true,
+			description:   "no debe recorrer más de 4 saltos desde el anime de partida",
+		},
+		{
+			name: "anime relacionado inexistente",
+			graph: map[string][]dto.AnimeRelated{
+				"a": {related("no-existe", dto.RelationSequel)},
+			},
+			root:           "a",
+			wantOrder:      []string{"a", "no-existe"},
+			wantUnresolved: []string{"no-existe"},
+			description:    "un anime relacionado que no existe se conserva solo con su ID y título",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scraper := &mocks.ScraperStub{
+				AnimeInfoFn: func(_ context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+					relations, ok := tc.graph[idAnime]
+					if !ok {
+						return dto.AnimeInfoResponse{}, &errs.UpstreamStatusError{Code: 404, URL: idAnime}
+					}
+					result := mocks.MockAnimeInfoResponse()
+					result.ID = idAnime
+					result.AnimeRelated = relations
+					return result, nil
+				},
+			}
+			service := newTestService(t, scraper)
+
+			franchise, err := service.Franchise(context.Background(), tc.root)
+			if err != nil {
+				t.Fatalf("Franchise() error = %v", err)
+			}
+
+			var order []string
+			for _, entry := range franchise.WatchOrder {
+				order = append(order, entry.Anime.ID)
+			}
+			if !slices.Equal(order, tc.wantOrder) {
+				t.Errorf("orden = %v, want %v (%s)", order, tc.wantOrder, tc.description)
+			}
+			if !slices.Equal(franchise.Unresolved, tc.wantUnresolved) {
+				t.Errorf("Unresolved = %v, want %v", franchise.Unresolved, tc.wantUnresolved)
+			}
+			if franchise.Truncated != tc.wantTruncated {
+				t.Errorf("Truncated = %v, want %v", franchise.Truncated, tc.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestFranchiseRootNotFound(t *testing.T) {
+	scraper := &mocks.ScraperStub{
+		AnimeInfoFn: func(_ context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+			return dto.AnimeInfoResponse{}, &errs.UpstreamStatusError{Code: 404, URL: idAnime}
+		},
+	}
+	service := newTestService(t, scraper)
+
+	if _, err := service.Franchise(context.Background(), "no-existe"); err == nil {
+		t.Fatal("Franchise() error = nil, want error para un anime inexistente")
+	}
+}
@@ -8,9 +8,12 @@ package animeflv
 import (
 	"bytes"
 	_ "embed"
+	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/dst3v3n/api-anime/internal/adapters/scrapers/animeflv"
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
 )
 
 // fixtures HTML embebidos para pruebas //
@@ -27,6 +30,12 @@ var searchAnimeHTML []byte
 //go:embed fixtures/search_anime_fatal.html
 var searchAnimeFatalHTML []byte
 
+//go:embed fixtures/search_empty.html
+var searchEmptyHTML []byte
+
+//go:embed fixtures/search_no_grid.html
+var searchNoGridHTML []byte
+
 //go:embed fixtures/anime_info.html
 var animeInfoHTML []byte
 
@@ -45,6 +54,9 @@ var homeAnimeflvHTML []byte
 //go:embed fixtures/home_animeflv_fatal.html
 var homeAnimeflvFatalHTML []byte
 
+//go:embed fixtures/home_no_onair.html
+var homeNoOnAirHTML []byte
+
 func TestParseAnimeSearch(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -81,6 +93,20 @@ func TestParseAnimeSearch(t *testing.T) {
 			expectedCount: 0,
 			description:   "no deberia parsear correctamente la lista de animes debido a error simulado",
 		},
+		{
+			name:          "búsqueda sin coincidencias",
+			htmlContent:   searchEmptyHTML,
+			wantError:     false,
+			expectedCount: 0,
+			description:   "una cuadrícula vacía es un resultado vacío y no un cambio de estructura",
+		},
+		{
+			name:          "búsqueda sin cuadrícula",
+			htmlContent:   searchNoGridHTML,
+			wantError:     true,
+			expectedCount: 0,
+			description:   "si falta la cuadrícula de animes debe reportarse un error de parsing",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -159,16 +185,18 @@ func TestParseAnimeInfo(t *testing.T) {
 
 func TestParseLinksEpisode(t *testing.T) {
 	testCases := []struct {
-		name        string
-		htmlContent []byte
-		wantError   bool
-		description string
+		name          string
+		htmlContent   []byte
+		wantError     bool
+		wantLanguages []dto.Language
+		description   string
 	}{
 		{
-			name:        "enlaces de episodio exitosos",
-			htmlContent: episodeLinksHTML,
-			wantError:   false,
-			description: "debe parsear correctamente los enlaces del episodio",
+			name:          "enlaces de episodio exitosos",
+			htmlContent:   episodeLinksHTML,
+			wantError:     false,
+			wantLanguages: []dto.Language{dto.LanguageSUB, dto.LanguageLAT},
+			description:   "debe parsear los enlaces de todos los idiomas del episodio",
 		},
 		{
 			name:        "enlaces de episodio fatal",
@@ -183,11 +211,21 @@ func TestParseLinksEpisode(t *testing.T) {
 			parser := animeflv.NewParser()
 			reader := bytes.NewReader(tc.htmlContent)
 
-			_, err := parser.ParseLinks(reader, "naruto-shippuden-hd", 220)
+			result, err := parser.ParseLinks(reader, "naruto-shippuden-hd", 220)
 			if (err != nil) != tc.wantError {
 				t.Errorf("error inesperado al parsear enlaces del episodio: %v", err)
 				return
 			}
+
+			var languages []dto.Language
+			for _, link := range result.Link {
+				if !slices.Contains(languages, link.Language) {
+					languages = append(languages, link.Language)
+				}
+			}
+			if !slices.Equal(languages, tc.wantLanguages) {
+				t.Errorf("idiomas de los enlaces = %v, want %v", languages, tc.wantLanguages)
+			}
 		})
 	}
 }
@@ -257,3 +295,363 @@ func TestParseRecent(t *testing.T) {
 		})
 	}
 }
+
+func TestParseGenres(t *testing.T) {
+	testCases := []struct {
+		name          string
+		htmlContent   []byte
+		wantError     bool
+		expectedCount int
+		description   string
+	}{
+		{
+			name:          "géneros del formulario de búsqueda",
+			htmlContent:   searchAnimeAllHTML,
+			wantError:     false,
+			expectedCount: 40,
+			description:   "debe parsear todos los géneros del filtro de /browse",
+		},
+		{
+			name:          "página sin formulario de filtros",
+			htmlContent:   homeAnimeflvHTML,
+			wantError:     true,
+			expectedCount: 0,
+			description:   "la página de inicio no contiene el filtro de géneros",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := animeflv.NewParser()
+
+			genres, err := parser.ParseGenres(bytes.NewReader(tc.htmlContent))
+
+			if (err != nil) != tc.wantError {
+				t.Errorf("ParseGenres() error = %v, wantError %v", err, tc.wantError)
+				return
+			}
+
+			if !tc.wantError {
+				if len(genres) != tc.expectedCount {
+					t.Errorf("conteo de géneros incorrecto: got %d, want %d", len(genres), tc.expectedCount)
+				}
+				want := dto.Genre{Slug: "artes-marciales", Name: "Artes Marciales"}
+				if !slices.Contains(genres, want) {
+					t.Errorf("ParseGenres() no contiene %+v", want)
+				}
+				if !slices.Contains(genres, dto.Genre{Slug: "accion", Name: "Acción"}) {
+					t.Error("ParseGenres() debe decodificar las entidades HTML del nombre")
+				}
+			}
+		})
+	}
+}
+
+func TestParseLinksDownloads(t *testing.T) {
+	testCases := []struct {
+		name        string
+		language    dto.Language
+		wantCount   int
+		description string
+	}{
+		{
+			name:        "descargas subtituladas",
+			language:    dto.LanguageSUB,
+			wantCount:   4,
+			description: "debe parsear las filas SUB de la tabla de descargas",
+		},
+		{
+			name:        "descargas en latino",
+			language:    dto.LanguageLAT,
+			wantCount:   3,
+			description: "debe parsear las filas LAT de la tabla de descargas",
+		},
+	}
+
+	parser := animeflv.NewParser()
+	result, err := parser.ParseLinks(bytes.NewReader(episodeLinksHTML), "naruto-shippuden-hd", 220)
+	if err != nil {
+		t.Fatalf("ParseLinks() error = %v", err)
+	}
+
+	want := dto.DownloadSource{
+		Server:   "MEGA",
+		Format:   "MP4",
+		Language: dto.LanguageSUB,
+		URL:      "https://mega.nz/#!RatzjT4Y!YVsNH-lKc_t4Phd_BGh4VAa5oS0RFE7il_0VX7qF_4I",
+	}
+	if len(result.Downloads) == 0 || result.Downloads[0] != want {
+		t.Fatalf("primera descarga = %+v, want %+v", result.Downloads, want)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			count := 0
+			for _, download := range result.Downloads {
+				if download.Language != tc.language {
+					continue
+				}
+				count++
+				if download.Server == "" || download.Format == "" || download.URL == "" {
+					t.Errorf("descarga con datos incompletos: %+v", download)
+				}
+			}
+			if count != tc.wantCount {
+				t.Errorf("descargas %s = %d, want %d", tc.language, count, tc.wantCount)
+			}
+		})
+	}
+}
+
+func TestParseLinksMeta(t *testing.T) {
+	middleEpisodeHTML := []byte(`<html><body>
+		<div class="CapNv">
+			<a href="/ver/naruto-219" class="CapNvPv fa-chevron-left">ANTERIOR</a>
+			<a href="/anime/naruto" class="CapNvLs fa-th-list"></a>
+			<a href="/ver/naruto-221" class="CapNvNx fa-chevron-right">SIGUIENTE</a>
+		</div>
+		<script type="text/javascript">
+			var anime_id = 2;
+			var episode_id = 769;
+			var episode_number = 220;
+			var videos = {"SUB":[{"server":"mega","title":"MEGA","code":"https://mega.nz/embed/abc"}]};
+		</script>
+	</body></html>`)
+
+	testCases := []struct {
+		name        string
+		htmlContent []byte
+		want        dto.LinkResponse
+		description string
+	}{
+		{
+			name:        "último episodio",
+			htmlContent: episodeLinksHTML,
+			want:        dto.LinkResponse{AnimeID: 2, EpisodeID: 768, PrevEpisode: 219, NextEpisode: 0},
+			description: "el último episodio solo enlaza al anterior",
+		},
+		{
+			name:        "episodio intermedio",
+			htmlContent: middleEpisodeHTML,
+			want:        dto.LinkResponse{AnimeID: 2, EpisodeID: 769, PrevEpisode: 219, NextEpisode: 221},
+			description: "un episodio intermedio enlaza al anterior y al siguiente",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := animeflv.NewParser()
+
+			result, err := parser.ParseLinks(bytes.NewReader(tc.htmlContent), "naruto", 220)
+			if err != nil {
+				t.Fatalf("ParseLinks() error = %v", err)
+			}
+
+			if result.AnimeID != tc.want.AnimeID || result.EpisodeID != tc.want.EpisodeID {
+				t.Errorf("IDs = (%d, %d), want (%d, %d)", result.AnimeID, result.EpisodeID, tc.want.AnimeID, tc.want.EpisodeID)
+			}
+			if result.PrevEpisode != tc.want.PrevEpisode || result.NextEpisode != tc.want.NextEpisode {
+				t.Errorf("navegación = (%d, %d), want (%d, %d)", result.PrevEpisode, result.NextEpisode, tc.want.PrevEpisode, tc.want.NextEpisode)
+			}
+		})
+	}
+}
+
+func TestParseOnAir(t *testing.T) {
+	testCases := []struct {
+		name        string
+		htmlContent []byte
+		wantError   bool
+		description string
+	}{
+		{
+			name:        "animes en emisión",
+			htmlContent: homeAnimeflvHTML,
+			wantError:   false,
+			description: "debe parsear la barra lateral de animes en emisión",
+		},
+		{
+			name:        "página sin barra lateral",
+			htmlContent: searchAnimeAllHTML,
+			wantError:   true,
+			description: "la página de búsqueda no contiene animes en emisión",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := animeflv.NewParser()
+
+			results, err := parser.ParseOnAir(bytes.NewReader(tc.htmlContent))
+			if (err != nil) != tc.wantError {
+				t.Errorf("ParseOnAir() error = %v, wantError %v", err, tc.wantError)
+				return
+			}
+
+			if !tc.wantError {
+				want := dto.AnimeRef{ID: "one-piece-tv", Title: "One Piece", Type: dto.Anime}
+				if len(results) == 0 || results[0] != want {
+					t.Errorf("primer anime en emisión = %+v, want %+v", results, want)
+				}
+				for _, anime := range results {
+					if anime.ID == "" || anime.Title == "" {
+						t.Errorf("anime en emisión con datos incompletos: %+v", anime)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParseHome(t *testing.T) {
+	testCases := []struct {
+		name        string
+		htmlContent []byte
+		wantError   bool
+		wantNoOnAir bool
+		description string
+	}{
+		{
+			name:        "página de inicio completa",
+			htmlContent: homeAnimeflvHTML,
+			wantError:   false,
+			description: "debe parsear todas las secciones de una sola lectura",
+		},
+		{
+			name:        "página de inicio fatal",
+			htmlContent: homeAnimeflvFatalHTML,
+			wantError:   true,
+			description: "no deberia parsear la página de inicio debido a error simulado",
+		},
+		{
+			name:        "página de inicio sin animes en emisión",
+			htmlContent: homeNoOnAirHTML,
+			wantError:   false,
+			wantNoOnAir: true,
+			description: "la barra lateral ausente debe quedar vacía sin afectar a las demás secciones",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := animeflv.NewParser()
+
+			home, err := parser.ParseHome(bytes.NewReader(tc.htmlContent))
+			if (err != nil) != tc.wantError {
+				t.Errorf("ParseHome() error = %v, wantError %v", err, tc.wantError)
+				return
+			}
+
+			if !tc.wantError {
+				if len(home.RecentAnime) == 0 || len(home.RecentEpisodes) == 0 || (len(home.OnAir) == 0) != tc.wantNoOnAir {
+					t.Errorf("ParseHome() secciones: %d animes, %d episodios, %d en emisión (%s)",
+						len(home.RecentAnime), len(home.RecentEpisodes), len(home.OnAir), tc.description)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAnimeInfoDetails(t *testing.T) {
+	parser := animeflv.NewParser()
+	result, err := parser.ParseAnimeInfo(bytes.NewReader(animeInfoHTML), "naruto-shippuden-hd")
+	if err != nil {
+		t.Fatalf("ParseAnimeInfo() error = %v", err)
+	}
+
+	testCases := []struct {
+		name        string
+		got         any
+		want        any
+		description string
+	}{
+		{
+			name:        "títulos alternativos",
+			got:         result.AltTitles,
+			want:        []string{"NARUTO 疾風伝"},
+			description: "debe extraer los títulos alternativos de la ficha",
+		},
+		{
+			name:        "votos",
+			got:         result.Votes,
+			want:        10396,
+			description: "debe extraer el número de votos",
+		},
+		{
+			name:        "seguidores",
+			got:         result.Followers,
+			want:        77105,
+			description: "debe extraer el número de seguidores",
+		},
+		{
+			name:        "banner",
+			got:         result.Banner,
+			want:        "/uploads/animes/banners/3.jpg",
+			description: "debe extraer la URL del banner del estilo de fondo",
+		},
+		{
+			name:        "tipos de relación",
+			got:         []dto.RelationType{result.AnimeRelated[0].Type, result.AnimeRelated[1].Type, result.AnimeRelated[5].Type},
+			want:        []dto.RelationType{dto.RelationPrequel, dto.RelationSideStory, dto.RelationSequel},
+			description: "debe clasificar Precuela, Historia Paralela y Secuela",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if fmt.Sprint(tc.got) != fmt.Sprint(tc.want) {
+				t.Errorf("%s = %v, want %v", tc.name, tc.got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseAnimeInfoEpisodes(t *testing.T) {
+	parser := animeflv.NewParser()
+	result, err := parser.ParseAnimeInfo(bytes.NewReader(animeInfoHTML), "naruto-shippuden-hd")
+	if err != nil {
+		t.Fatalf("ParseAnimeInfo() error = %v", err)
+	}
+	if len(result.Episodes) == 0 {
+		t.Fatal("ParseAnimeInfo() no retornó episodios")
+	}
+
+	testCases := []struct {
+		name        string
+		got         any
+		want        any
+		description string
+	}{
+		{
+			name: "primer episodio",
+			got:  result.Episodes[0],
+			want: dto.Episode{
+				Number:       500,
+				ID:           45227,
+				URL:          "/ver/naruto-shippuden-hd-500",
+				ThumbnailURL: "https://cdn.animeflv.net/screenshots/3/500/th_3.jpg",
+			},
+			description: "debe combinar número e ID de \"var episodes\" con el ID numérico de \"var anime_info\"",
+		},
+		{
+			name:        "números de episodio",
+			got:         len(result.EpisodeNumbers()),
+			want:        len(result.Episodes),
+			description: "EpisodeNumbers debe retornar un número por episodio",
+		},
+		{
+			name:        "primer número",
+			got:         result.EpisodeNumbers()[0],
+			want:        500,
+			description: "EpisodeNumbers debe conservar el orden de Episodes",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if fmt.Sprint(tc.got) != fmt.Sprint(tc.want) {
+				t.Errorf("%s = %v, want %v", tc.name, tc.got, tc.want)
+			}
+		})
+	}
+}
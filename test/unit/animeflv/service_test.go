@@ -0,0 +1,815 @@
+// Package animeflv contiene tests unitarios para los servicios de dominio de AnimeFlv.
+// Este archivo (service_test.go) verifica el comportamiento de los servicios usando
+// un scraper simulado (mocks.ScraperStub), sin acceso a la red ni a Valkey.
+package animeflv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	scraper "github.com/dst3v3n/api-anime/internal/adapters/scrapers/animeflv"
+	"github.com/dst3v3n/api-anime/internal/config"
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+	"github.com/dst3v3n/api-anime/internal/domain/services/animeflv"
+	"github.com/dst3v3n/api-anime/internal/mocks"
+	"github.com/dst3v3n/api-anime/internal/ports"
+	"github.com/rs/zerolog"
+)
+
+// newTestService construye el servicio con el scraper indicado y caché desactivado.
+func newTestService(t *testing.T, scraper ports.ScraperPort, onClose ...func()) *animeflv.AnimeflvService {
+	t.Helper()
+
+	service, err := animeflv.NewAnimeflvServiceWith(animeflv.Dependencies{
+		Scraper: scraper,
+		Config:  config.NewConfigWithDefaults(),
+		Logger:  zerolog.Nop(),
+		OnClose: onClose,
+	})
+	if err != nil {
+		t.Fatalf("error creando el servicio: %v", err)
+	}
+	return service
+}
+
+func TestNewAnimeflvServiceWithReleasesOnFailure(t *testing.T) {
+	testCases := []struct {
+		name        string
+		deps        animeflv.Dependencies
+		description string
+	}{
+		{
+			name:        "sin scraper",
+			deps:        animeflv.Dependencies{Config: config.NewConfigWithDefaults()},
+			description: "debe liberar los recursos si falta el scraper",
+		},
+		{
+			name:        "sin configuración",
+			deps:        animeflv.Dependencies{Scraper: &mocks.ScraperStub{}},
+			description: "debe liberar los recursos si falta la configuración",
+		},
+		{
+			name: "caché habilitado sin CachePort",
+			deps: animeflv.Dependencies{
+				Scraper: &mocks.ScraperStub{},
+				Config:  config.NewConfigWithDefaults().WithCache(true),
+			},
+			description: "debe liberar los recursos si el caché está habilitado sin implementación",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			released := 0
+			tc.deps.OnClose = []func(){func() { released++ }, func() { released++ }}
+
+			service, err := animeflv.NewAnimeflvServiceWith(tc.deps)
+			if err == nil || service != nil {
+				t.Fatalf("NewAnimeflvServiceWith() = %v, %v; want error", service, err)
+			}
+			if released != 2 {
+				t.Errorf("recursos liberados = %d, want 2 (%s)", released, tc.description)
+			}
+		})
+	}
+}
+
+func TestServiceClose(t *testing.T) {
+	testCases := []struct {
+		name         string
+		closeTimeout time.Duration
+		wantError    error
+		description  string
+	}{
+		{
+			name:         "cierre drena operaciones en curso",
+			closeTimeout: time.Second,
+			wantError:    nil,
+			description:  "debe esperar a que termine la operación en curso antes de liberar recursos",
+		},
+		{
+			name:         "cierre con plazo vencido cancela operaciones",
+			closeTimeout: 20 * time.Millisecond,
+			wantError:    context.DeadlineExceeded,
+			description:  "debe cancelar la operación pendiente y retornar el error del contexto",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			started := make(chan struct{})
+			release := make(chan struct{})
+			released := 0
+
+			scraper := &mocks.ScraperStub{
+				SearchFn: func(ctx context.Context) (dto.AnimeResponse, error) {
+					close(started)
+					select {
+					case <-release:
+						return mocks.MockAnimeResponse(), nil
+					case <-ctx.Done():
+						return dto.AnimeResponse{}, ctx.Err()
+					}
+				},
+			}
+			service := newTestService(t, scraper, func() { released++ })
+
+			callErr := make(chan error, 1)
+			go func() {
+				_, err := service.Search(context.Background())
+				callErr <- err
+			}()
+			<-started
+
+			if tc.wantError == nil {
+				time.AfterFunc(20*time.Millisecond, func() { close(release) })
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.closeTimeout)
+			defer cancel()
+
+			if err := service.Close(ctx); !errors.Is(err, tc.wantError) {
+				t.Errorf("Close() error = %v, want %v", err, tc.wantError)
+			}
+
+			if err := <-callErr; (err != nil) != (tc.wantError != nil) {
+				t.Errorf("operación en curso error = %v, want error: %v", err, tc.wantError != nil)
+			}
+
+			if released != 1 {
+				t.Errorf("recursos liberados %d veces, want 1", released)
+			}
+
+			if _, err := service.Search(context.Background()); !errors.Is(err, errs.ErrClosed) {
+				t.Errorf("operación tras Close error = %v, want %v", err, errs.ErrClosed)
+			}
+
+			if err := service.Close(context.Background()); err != nil {
+				t.Errorf("segundo Close() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestSearchIter(t *testing.T) {
+	testCases := []struct {
+		name        string
+		totalPages  uint
+		failPage    string
+		noResults   bool
+		stopAfter   int
+		wantItems   int
+		wantPages   int
+		wantError   bool
+		description string
+	}{
+		{
+			name:        "recorre todas las páginas",
+			totalPages:  3,
+			wantItems:   15,
+			wantPages:   3,
+			description: "debe entregar los animes de las tres páginas",
+		},
+		{
+			name:        "break temprano no solicita más páginas",
+			totalPages:  3,
+			stopAfter:   2,
+			wantItems:   2,
+			wantPages:   1,
+			description: "debe detenerse sin pedir la segunda página",
+		},
+		{
+			name:        "error en la segunda página",
+			totalPages:  3,
+			failPage:    "2",
+			wantItems:   5,
+			wantPages:   2,
+			wantError:   true,
+			description: "debe entregar la primera página y luego el error",
+		},
+		{
+			name:        "resultado de una sola página",
+			totalPages:  0,
+			wantItems:   5,
+			wantPages:   1,
+			description: "sin paginación debe entregar solo la primera página",
+		},
+		{
+			name:        "búsqueda sin coincidencias",
+			noResults:   true,
+			wantItems:   0,
+			wantPages:   1,
+			description: "una búsqueda sin resultados termina sin error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pages := 0
+			scraper := &mocks.ScraperStub{
+				SearchAnimeFn: func(_ context.Context, _ string, page string) (dto.AnimeResponse, error) {
+					pages++
+					if page == tc.failPage {
+						return dto.AnimeResponse{}, &errs.ParseError{Page: errs.PageBrowse, Field: "animes"}
+					}
+					if tc.noResults {
+						return dto.AnimeResponse{}, nil
+					}
+					return dto.AnimeResponse{Animes: mocks.MockAnimeStructList(), TotalPages: tc.totalPages}, nil
+				},
+			}
+			service := newTestService(t, scraper)
+
+			items := 0
+			var gotErr error
+			for _, err := range service.SearchIter(context.Background(), "naruto") {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				items++
+				if items == tc.stopAfter {
+					break
+				}
+			}
+
+			if (gotErr != nil) != tc.wantError {
+				t.Errorf("error = %v, want error: %v", gotErr, tc.wantError)
+			}
+			if items != tc.wantItems {
+				t.Errorf("animes entregados = %d, want %d", items, tc.wantItems)
+			}
+			if pages != tc.wantPages {
+				t.Errorf("páginas solicitadas = %d, want %d", pages, tc.wantPages)
+			}
+		})
+	}
+}
+
+func TestAnimeInfoMany(t *testing.T) {
+	var active, maxActive atomic.Int32
+	scraper := &mocks.ScraperStub{
+		AnimeInfoFn: func(_ context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+			current := active.Add(1)
+			defer active.Add(-1)
+			for {
+				prev := maxActive.Load()
+				if current <= prev || maxActive.CompareAndSwap(prev, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+
+			if idAnime == "no-existe" {
+				return dto.AnimeInfoResponse{}, &errs.UpstreamStatusError{Code: 404, URL: idAnime}
+			}
+			result := mocks.MockAnimeInfoResponse()
+			result.ID = idAnime
+			return result, nil
+		},
+	}
+	service := newTestService(t, scraper)
+
+	ids := []string{"one-piece-tv", "no-existe", "naruto", "", "bleach", "haikyuu", "naruto"}
+	results, failures := service.AnimeInfoMany(context.Background(), ids, dto.BatchOptions{Concurrency: 2})
+
+	if len(results) != 4 {
+		t.Errorf("resultados exitosos = %d, want 4", len(results))
+	}
+	if !errors.Is(failures["no-existe"], errs.ErrNotFound) {
+		t.Errorf("error de no-existe = %v, want %v", failures["no-existe"], errs.ErrNotFound)
+	}
+	if !errors.Is(failures[""], errs.ErrInvalidInput) {
+		t.Errorf("error de ID vacío = %v, want %v", failures[""], errs.ErrInvalidInput)
+	}
+	if got := maxActive.Load(); got > 2 {
+		t.Errorf("consultas simultáneas = %d, want <= 2", got)
+	}
+}
+
+func TestLinksRangeCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scraper := &mocks.ScraperStub{
+		AnimeInfoFn: func(_ context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+			result := mocks.MockAnimeInfoResponseFinished()
+			result.ID = idAnime
+			result.Episodes = mocks.MockEpisodes(idAnime, 2012, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1)
+			return result, nil
+		},
+		LinksFn: func(ctx context.Context, idAnime string, episode uint) (dto.LinkResponse, error) {
+			// El consumidor cancela a mitad del rango: las consultas a partir del episodio 5 no terminan.
+			if episode >= 5 {
+				cancel()
+				<-ctx.Done()
+				return dto.LinkResponse{}, ctx.Err()
+			}
+			result := mocks.MockLinkResponse()
+			result.ID = idAnime
+			result.Episode = episode
+			return result, nil
+		},
+	}
+	service := newTestService(t, scraper)
+
+	var last error
+	var lastLinks dto.LinkResponse
+	items := 0
+	for links, err := range service.LinksRange(ctx, "naruto", 1, 10) {
+		items++
+		last, lastLinks = err, links
+	}
+
+	if items >= 11 {
+		t.Errorf("elementos entregados = %d, want menos de 11 tras la cancelación", items)
+	}
+	if !errors.Is(last, context.Canceled) || lastLinks.Episode != 0 {
+		t.Errorf("último elemento = (%+v, %v), want el error del contexto sin episodio", lastLinks, last)
+	}
+}
+
+func TestLinksRange(t *testing.T) {
+	testCases := []struct {
+		name        string
+		from        uint
+		to          uint
+		all         bool
+		stopAfter   int
+		wantItems   int
+		wantErrors  int
+		description string
+	}{
+		{
+			name:        "rango de episodios",
+			from:        3,
+			to:          6,
+			wantItems:   4,
+			wantErrors:  1,
+			description: "debe entregar los episodios 3 a 6, con el episodio 5 fallido",
+		},
+		{
+			name:        "todos los episodios",
+			all:         true,
+			wantItems:   10,
+			wantErrors:  1,
+			description: "debe entregar los 10 episodios disponibles",
+		},
+		{
+			name:        "rango fuera de los episodios disponibles",
+			from:        20,
+			to:          30,
+			wantItems:   0,
+			description: "no debe entregar episodios inexistentes",
+		},
+		{
+			name:        "rango invertido",
+			from:        6,
+			to:          3,
+			wantItems:   1,
+			wantErrors:  1,
+			description: "debe entregar un error de parámetro inválido",
+		},
+		{
+			name:        "break temprano",
+			all:         true,
+			stopAfter:   1,
+			wantItems:   1,
+			description: "debe cancelar las consultas pendientes sin bloquearse",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scraper := &mocks.ScraperStub{
+				AnimeInfoFn: func(_ context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+					result := mocks.MockAnimeInfoResponseFinished()
+					result.ID = idAnime
+					result.Episodes = mocks.MockEpisodes(idAnime, 2012, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1)
+					return result, nil
+				},
+				LinksFn: func(_ context.Context, idAnime string, episode uint) (dto.LinkResponse, error) {
+					if episode == 5 {
+						return dto.LinkResponse{}, &errs.ParseError{Page: errs.PageEpisode, Field: "videos"}
+					}
+					result := mocks.MockLinkResponse()
+					result.ID = idAnime
+					result.Episode = episode
+					return result, nil
+				},
+			}
+			service := newTestService(t, scraper)
+
+			seq := service.LinksRange(context.Background(), "naruto", tc.from, tc.to)
+			if tc.all {
+				seq = service.AllLinks(context.Background(), "naruto")
+			}
+
+			items, failures := 0, 0
+			seen := map[uint]bool{}
+			for links, err := range seq {
+				items++
+				if err != nil {
+					failures++
+				} else if seen[links.Episode] {
+					t.Errorf("episodio %d entregado más de una vez", links.Episode)
+				}
+				seen[links.Episode] = true
+				if items == tc.stopAfter {
+					break
+				}
+			}
+
+			if items != tc.wantItems {
+				t.Errorf("resultados entregados = %d, want %d", items, tc.wantItems)
+			}
+			if tc.stopAfter == 0 && failures != tc.wantErrors {
+				t.Errorf("errores entregados = %d, want %d", failures, tc.wantErrors)
+			}
+		})
+	}
+}
+
+func TestLinksLanguage(t *testing.T) {
+	testCases := []struct {
+		name        string
+		languages   []dto.Language
+		wantLinks   int
+		wantErr     error
+		description string
+	}{
+		{
+			name:        "sin filtro",
+			wantLinks:   6,
+			description: "sin idiomas debe retornar los enlaces de todos los idiomas",
+		},
+		{
+			name:        "solo doblaje latino",
+			languages:   []dto.Language{dto.LanguageLAT},
+			wantLinks:   1,
+			description: "debe conservar solo los enlaces LAT",
+		},
+		{
+			name:        "idioma sin enlaces",
+			languages:   []dto.Language{"CAST"},
+			wantErr:     errs.ErrNotFound,
+			description: "un idioma sin enlaces debe retornar ErrNotFound",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := newTestService(t, &mocks.ScraperStub{})
+
+			result, err := service.Links(context.Background(), "one-piece-tv", 1090, tc.languages...)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Links() error = %v, want %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(result.Link) != tc.wantLinks {
+				t.Errorf("enlaces = %d, want %d", len(result.Link), tc.wantLinks)
+			}
+			for _, link := range result.Link {
+				if len(tc.languages) > 0 && !slices.Contains(tc.languages, link.Language) {
+					t.Errorf("enlace con idioma %q no solicitado", link.Language)
+				}
+			}
+		})
+	}
+}
+
+func TestBrowseNormalization(t *testing.T) {
+	testCases := []struct {
+		name        string
+		filter      dto.BrowseFilter
+		page        uint
+		wantFilter  dto.BrowseFilter
+		wantPage    string
+		wantError   error
+		description string
+	}{
+		{
+			name: "películas de acción de 2024 por calificación",
+			filter: dto.BrowseFilter{
+				Genres: []string{" Accion ", "accion", "", "DRAMA"},
+				Years:  []int{2024, 2024},
+				Types:  []dto.BrowseType{dto.BrowseMovie, dto.BrowseMovie},
+				Status: []dto.BrowseStatus{dto.BrowseFinalizado, dto.BrowseFinalizado},
+				Order:  dto.OrderRating,
+			},
+			page: 3,
+			wantFilter: dto.BrowseFilter{
+				Genres: []string{"accion", "drama"},
+				Years:  []int{2024},
+				Types:  []dto.BrowseType{dto.BrowseMovie},
+				Status: []dto.BrowseStatus{dto.BrowseFinalizado},
+				Order:  dto.OrderRating,
+			},
+			wantPage:    "3",
+			description: "los géneros deben pasar a minúsculas y descartarse los valores vacíos o duplicados",
+		},
+		{
+			name:        "orden por defecto",
+			filter:      dto.BrowseFilter{Order: dto.OrderDefault},
+			page:        0,
+			wantFilter:  dto.BrowseFilter{},
+			wantPage:    "1",
+			description: "OrderDefault no debe enviarse y la página 0 equivale a la primera",
+		},
+		{
+			name:        "año cero",
+			filter:      dto.BrowseFilter{Years: []int{0}},
+			wantError:   errs.ErrInvalidInput,
+			description: "un año no positivo debe rechazarse sin consultar el sitio",
+		},
+		{
+			name:        "año negativo",
+			filter:      dto.BrowseFilter{Years: []int{2024, -1}},
+			wantError:   errs.ErrInvalidInput,
+			description: "un año no positivo debe rechazarse sin consultar el sitio",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotFilter dto.BrowseFilter
+			var gotPage string
+			fetches := 0
+			service := newTestService(t, &mocks.ScraperStub{
+				BrowseFn: func(_ context.Context, filter dto.BrowseFilter, page string) (dto.AnimeResponse, error) {
+					fetches++
+					gotFilter, gotPage = filter, page
+					return mocks.MockAnimeResponse(), nil
+				},
+			})
+
+			_, err := service.Browse(context.Background(), tc.filter, tc.page)
+			if !errors.Is(err, tc.wantError) {
+				t.Fatalf("Browse() error = %v, want %v (%s)", err, tc.wantError, tc.description)
+			}
+			if tc.wantError != nil {
+				if fetches != 0 {
+					t.Errorf("consultas al scraper = %d, want 0 (%s)", fetches, tc.description)
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(gotFilter, tc.wantFilter) || gotPage != tc.wantPage {
+				t.Errorf("Browse() envió %+v página %q, want %+v página %q (%s)", gotFilter, gotPage, tc.wantFilter, tc.wantPage, tc.description)
+			}
+		})
+	}
+}
+
+func TestHomeSnapshotShared(t *testing.T) {
+	var fetches atomic.Int32
+	scraper := &mocks.ScraperStub{
+		HomeFn: func(context.Context) (dto.HomeSnapshot, error) {
+			fetches.Add(1)
+			return mocks.MockHomeSnapshot(), nil
+		},
+	}
+	service, err := animeflv.NewAnimeflvServiceWith(animeflv.Dependencies{
+		Scraper: scraper,
+		Cache:   mocks.NewCacheStub(),
+		Config:  config.NewConfigWithDefaults().WithCache(true),
+		Logger:  zerolog.Nop(),
+	})
+	if err != nil {
+		t.Fatalf("error creando el servicio: %v", err)
+	}
+
+	ctx := context.Background()
+	if animes, err := service.RecentAnime(ctx); err != nil || len(animes) == 0 {
+		t.Errorf("RecentAnime() = %d animes, error %v", len(animes), err)
+	}
+	if episodes, err := service.RecentEpisode(ctx); err != nil || len(episodes) == 0 {
+		t.Errorf("RecentEpisode() = %d episodios, error %v", len(episodes), err)
+	}
+	if onAir, err := service.OnAir(ctx); err != nil || len(onAir) == 0 {
+		t.Errorf("OnAir() = %d animes, error %v", len(onAir), err)
+	}
+
+	if got := fetches.Load(); got != 1 {
+		t.Errorf("peticiones a la página de inicio = %d, want 1", got)
+	}
+}
+
+func TestHomeSectionsFailIndependently(t *testing.T) {
+	service := newTestService(t, &mocks.ScraperStub{
+		HomeFn: func(context.Context) (dto.HomeSnapshot, error) {
+			return scraper.NewParser().ParseHome(bytes.NewReader(homeNoOnAirHTML))
+		},
+	})
+	ctx := context.Background()
+
+	if animes, err := service.RecentAnime(ctx); err != nil || len(animes) == 0 {
+		t.Errorf("RecentAnime() = %d animes, error %v", len(animes), err)
+	}
+	if episodes, err := service.RecentEpisode(ctx); err != nil || len(episodes) == 0 {
+		t.Errorf("RecentEpisode() = %d episodios, error %v", len(episodes), err)
+	}
+
+	_, err := service.OnAir(ctx)
+	var parseErr *errs.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Page != errs.PageHome || parseErr.Field != "onair" {
+		t.Errorf("OnAir() error = %v, want *errs.ParseError {%s onair}", err, errs.PageHome)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	today := time.Now()
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+	nextDates := map[string]string{
+		"one-piece-tv":    today.AddDate(0, 0, 1).Format("2006-01-02"),
+		"detective-conan": today.AddDate(0, 0, 3).Format("2006-01-02"),
+		"one-punch-man-3": "",
+	}
+
+	var fetches atomic.Int32
+	scraper := &mocks.ScraperStub{
+		AnimeInfoFn: func(_ context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+			fetches.Add(1)
+			result := mocks.MockAnimeInfoResponse()
+			result.ID = idAnime
+			result.NextEpisode = nextDates[idAnime]
+			result.Episodes = mocks.MockEpisodes(idAnime, 130, 1, 2, 3)
+			return result, nil
+		},
+	}
+	service := newTestService(t, scraper)
+
+	testCases := []struct {
+		name        string
+		weekStart   time.Time
+		wantFetches int32
+		wantDays    map[int]string
+		wantEpisode int
+		description string
+	}{
+		{
+			name:        "semana actual",
+			weekStart:   today,
+			wantFetches: 3,
+			wantDays:    map[int]string{1: "one-piece-tv", 3: "detective-conan"},
+			wantEpisode: 4,
+			description: "la primera consulta resuelve todas las series en emisión",
+		},
+		{
+			name:        "semana siguiente",
+			weekStart:   today.AddDate(0, 0, 7),
+			wantFetches: 3,
+			wantDays:    map[int]string{1: "one-piece-tv", 3: "detective-conan"},
+			wantEpisode: 5,
+			description: "las series vigentes se reutilizan y se proyectan una semana",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := service.Schedule(context.Background(), tc.weekStart)
+			if err != nil {
+				t.Fatalf("Schedule() error = %v", err)
+			}
+
+			if got := fetches.Load(); got != tc.wantFetches {
+				t.Errorf("consultas de AnimeInfo acumuladas = %d, want %d", got, tc.wantFetches)
+			}
+			if len(schedule.Days) != 7 {
+				t.Fatalf("días del calendario = %d, want 7", len(schedule.Days))
+			}
+			for i, day := range schedule.Days {
+				wantID, ok := tc.wantDays[i]
+				if !ok {
+					if len(day.Entries) != 0 {
+						t.Errorf("día %d con emisiones inesperadas: %+v", i, day.Entries)
+					}
+					continue
+				}
+				if len(day.Entries) != 1 || day.Entries[0].Anime.ID != wantID {
+					t.Errorf("día %d = %+v, want %s", i, day.Entries, wantID)
+					continue
+				}
+				if day.Entries[0].Episode != tc.wantEpisode {
+					t.Errorf("episodio de %s = %d, want %d", wantID, day.Entries[0].Episode, tc.wantEpisode)
+				}
+				if day.Weekday != day.Entries[0].AirDate.Weekday() {
+					t.Errorf("día %s no coincide con la emisión %v", day.Weekday, day.Entries[0].AirDate)
+				}
+			}
+		})
+	}
+}
+
+func TestScheduleRefreshFailure(t *testing.T) {
+	const missingID = "serie-retirada"
+	var failing, withMissing atomic.Bool
+	var fetches atomic.Int32
+	scraper := &mocks.ScraperStub{
+		HomeFn: func(context.Context) (dto.HomeSnapshot, error) {
+			home := mocks.MockHomeSnapshot()
+			if withMissing.Load() {
+				home.OnAir = append(home.OnAir, dto.AnimeRef{ID: missingID, Title: "Serie retirada", Type: dto.Anime})
+			}
+			return home, nil
+		},
+		AnimeInfoFn: func(_ context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+			fetches.Add(1)
+			if failing.Load() {
+				return dto.AnimeInfoResponse{}, errs.ErrCircuitOpen
+			}
+			if idAnime == missingID {
+				return dto.AnimeInfoResponse{}, errs.ErrNotFound
+			}
+			result := mocks.MockAnimeInfoResponse()
+			result.ID = idAnime
+			result.NextEpisode = time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+			return result, nil
+		},
+	}
+	service := newTestService(t, scraper)
+
+	testCases := []struct {
+		name        string
+		failing     bool
+		missing     bool
+		cancel      bool
+		wantError   error
+		wantFetches int32 // Consultas nuevas de AnimeInfo; no se verifica con el contexto cancelado
+		description string
+	}{
+		{
+			name:        "todas las consultas fallan",
+			failing:     true,
+			wantError:   errs.ErrCircuitOpen,
+			wantFetches: 3,
+			description: "no debe retornar un calendario vacío sin error",
+		},
+		{
+			name:        "contexto cancelado",
+			cancel:      true,
+			wantError:   context.Canceled,
+			description: "debe retornar el error del contexto",
+		},
+		{
+			name:        "recuperación",
+			wantFetches: 3,
+			description: "las series que fallaron no se consideran vigentes y se vuelven a consultar",
+		},
+		{
+			name:        "una serie nueva falla",
+			missing:     true,
+			wantFetches: 1,
+			description: "una serie sin información no debe invalidar el calendario de las demás",
+		},
+		{
+			name:        "la serie nueva sigue fallando",
+			missing:     true,
+			wantFetches: 1,
+			description: "la serie fallida se vuelve a consultar sin retornar error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			failing.Store(tc.failing)
+			withMissing.Store(tc.missing)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.cancel {
+				cancel()
+			}
+
+			before := fetches.Load()
+			schedule, err := service.Schedule(ctx, time.Time{})
+			if !errors.Is(err, tc.wantError) {
+				t.Fatalf("Schedule() error = %v, want %v (%s)", err, tc.wantError, tc.description)
+			}
+			if got := fetches.Load() - before; !tc.cancel && got != tc.wantFetches {
+				t.Errorf("consultas de AnimeInfo = %d, want %d (%s)", got, tc.wantFetches, tc.description)
+			}
+			if tc.wantError == nil && schedule.UpdatedAt.IsZero() {
+				t.Error("UpdatedAt vacío tras refrescar el calendario")
+			}
+			if tc.wantError == nil {
+				entries := 0
+				for _, day := range schedule.Days {
+					entries += len(day.Entries)
+				}
+				if want := len(mocks.MockOnAir()); entries != want {
+					t.Errorf("emisiones en el calendario = %d, want %d (%s)", entries, want, tc.description)
+				}
+			}
+		})
+	}
+}
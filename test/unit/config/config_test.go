@@ -0,0 +1,102 @@
+// Package config_test contiene tests unitarios del package público config.
+// Este archivo (config_test.go) verifica, desde fuera del módulo interno, que un consumidor
+// puede construir una configuración con límites de peticiones propios por clase de operación
+// y que la validación rechaza URLs del sitio inválidas.
+package config_test
+
+import (
+	"testing"
+
+	anime "github.com/dst3v3n/api-anime"
+	"github.com/dst3v3n/api-anime/config"
+	"github.com/dst3v3n/api-anime/types"
+)
+
+func TestConfigWithRateLimits(t *testing.T) {
+	testCases := []struct {
+		name        string
+		browse      config.RateLimit
+		info        config.RateLimit
+		wantError   bool
+		description string
+	}{
+		{
+			name:        "límites propios",
+			browse:      config.RateLimit{Rate: 2, Burst: 4},
+			info:        config.RateLimit{Rate: 5, Burst: 10},
+			wantError:   false,
+			description: "los límites por clase deben aplicarse al scraper construido por New",
+		},
+		{
+			name:        "ritmo negativo",
+			browse:      config.RateLimit{Rate: -1, Burst: 4},
+			info:        config.DefaultRateLimit,
+			wantError:   true,
+			description: "un ritmo negativo debe rechazarse al construir la fachada",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.NewConfigWithDefaults().
+				WithRateLimits(tc.browse, tc.info, config.DefaultRateLimit, config.DefaultRateLimit)
+
+			service, err := anime.New(anime.WithConfig(cfg))
+			if (err != nil) != tc.wantError {
+				t.Fatalf("New() error = %v, wantError %v (%s)", err, tc.wantError, tc.description)
+			}
+			if tc.wantError {
+				return
+			}
+
+			want := map[types.RequestClass]config.RateLimit{
+				types.RequestBrowse:  tc.browse,
+				types.RequestInfo:    tc.info,
+				types.RequestEpisode: config.DefaultRateLimit,
+				types.RequestHome:    config.DefaultRateLimit,
+			}
+			for _, state := range service.RateLimits() {
+				limit := want[state.Class]
+				if state.ConfiguredRate != limit.Rate || state.Burst != limit.Burst {
+					t.Errorf("límite de %s = %v/%d, want %v/%d (%s)", state.Class, state.ConfiguredRate, state.Burst, limit.Rate, limit.Burst, tc.description)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigValidateScraperURLs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		validate    func() error
+		wantError   bool
+		description string
+	}{
+		{
+			name:        "valores por defecto",
+			validate:    config.NewConfigWithDefaults().Validate,
+			wantError:   false,
+			description: "la URL base por defecto debe ser válida",
+		},
+		{
+			name:        "mirror relativo",
+			validate:    config.NewConfigWithDefaults().WithMirrors("www4.animeflv.net").Validate,
+			wantError:   true,
+			description: "un mirror sin esquema debe rechazarse",
+		},
+		{
+			name:        "URL base con otro esquema",
+			validate:    config.NewConfigWithDefaults().WithBaseURL("ftp://animeflv.net").Validate,
+			wantError:   true,
+			description: "solo se aceptan URLs http(s)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.validate(); (err != nil) != tc.wantError {
+				t.Errorf("Validate() error = %v, wantError %v (%s)", err, tc.wantError, tc.description)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Package services contiene tests unitarios para los componentes de dominio compartidos.
+// Este archivo (cached_test.go) verifica la política cache-aside de services.Cached:
+// construcción de claves, aciertos y fallos de caché, resultados vacíos, manejo de errores
+// y copias de respaldo servidas con el circuit breaker abierto.
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+	"github.com/dst3v3n/api-anime/internal/domain/services"
+	"github.com/dst3v3n/api-anime/internal/mocks"
+)
+
+func TestCachedKey(t *testing.T) {
+	testCases := []struct {
+		name  string
+		parts []any
+		want  string
+	}{
+		{name: "sin partes", parts: nil, want: "search-anime"},
+		{name: "una parte", parts: []any{"all"}, want: "search-anime-all"},
+		{name: "varias partes", parts: []any{"naruto", "page", uint(2)}, want: "search-anime-naruto-page-2"},
+	}
+
+	cached := services.NewCached[dto.AnimeResponse](nil, false, "search-anime", nil)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := cached.Key(tc.parts...); got != tc.want {
+				t.Errorf("Key() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCachedGet(t *testing.T) {
+	fetchErr := errors.New("fallo del scraper")
+
+	testCases := []struct {
+		name        string
+		enabled     bool
+		setErr      error
+		results     [][]dto.AnimeStruct
+		fetchErr    error
+		wantFetches int
+		wantError   bool
+		description string
+	}{
+		{
+			name:        "segunda llamada servida desde caché",
+			enabled:     true,
+			results:     [][]dto.AnimeStruct{mocks.MockAnimeStructList()},
+			wantFetches: 1,
+			description: "la segunda llamada no debe consultar la fuente",
+		},
+		{
+			name:        "caché desactivado consulta siempre la fuente",
+			enabled:     false,
+			results:     [][]dto.AnimeStruct{mocks.MockAnimeStructList()},
+			wantFetches: 2,
+			description: "sin caché cada llamada consulta la fuente",
+		},
+		{
+			name:        "resultado vacío no se cachea",
+			enabled:     true,
+			results:     [][]dto.AnimeStruct{{}},
+			wantFetches: 2,
+			description: "un listado vacío no debe guardarse en caché",
+		},
+		{
+			name:        "error de la fuente se propaga y no se cachea",
+			enabled:     true,
+			fetchErr:    fetchErr,
+			wantFetches: 2,
+			wantError:   true,
+			description: "el error del scraper debe llegar al llamador",
+		},
+		{
+			name:        "error de escritura se ignora",
+			enabled:     true,
+			setErr:      errors.New("valkey caído"),
+			results:     [][]dto.AnimeStruct{mocks.MockAnimeStructList()},
+			wantFetches: 2,
+			description: "un fallo al guardar no debe afectar al resultado",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := mocks.NewCacheStub()
+			cache.SetErr = tc.setErr
+			cached := services.NewCached(cache, tc.enabled, "recent-anime", services.IsEmptySlice[dto.AnimeStruct])
+
+			fetches := 0
+			fetch := func(context.Context) ([]dto.AnimeStruct, error) {
+				fetches++
+				if tc.fetchErr != nil {
+					return nil, tc.fetchErr
+				}
+				return tc.results[0], nil
+			}
+
+			for range 2 {
+				result, err := cached.Get(context.Background(), cached.Key(), fetch)
+				if (err != nil) != tc.wantError {
+					t.Fatalf("Get() error = %v, want error: %v", err, tc.wantError)
+				}
+				if !tc.wantError && len(result) != len(tc.results[0]) {
+					t.Errorf("Get() retornó %d elementos, want %d", len(result), len(tc.results[0]))
+				}
+			}
+
+			if fetches != tc.wantFetches {
+				t.Errorf("consultas a la fuente = %d, want %d", fetches, tc.wantFetches)
+			}
+		})
+	}
+}
+
+func TestCachedWithTTL(t *testing.T) {
+	cache := mocks.NewCacheStub()
+	cached := services.NewCached(cache, true, "genres", services.IsEmptySlice[dto.Genre]).WithTTL(24 * time.Hour)
+
+	_, err := cached.Get(context.Background(), cached.Key(), func(context.Context) ([]dto.Genre, error) {
+		return mocks.MockGenres(), nil
+	})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if got := cache.TTLs["genres"]; got != 24*time.Hour {
+		t.Errorf("TTL de genres = %v, want %v", got, 24*time.Hour)
+	}
+}
+
+func TestCachedStaleWhenCircuitOpen(t *testing.T) {
+	testCases := []struct {
+		name        string
+		stale       bool
+		fetchErr    error
+		wantError   bool
+		description string
+	}{
+		{
+			name:        "circuito abierto",
+			stale:       true,
+			fetchErr:    errs.ErrCircuitOpen,
+			wantError:   false,
+			description: "con el circuito abierto debe servirse la copia de respaldo aunque la entrada expiró",
+		},
+		{
+			name:        "verificación anti-bot",
+			stale:       true,
+			fetchErr:    &errs.ChallengeError{URL: "/browse", Code: 403, Provider: "cloudflare"},
+			wantError:   true,
+			description: "una verificación anti-bot debe propagarse para distinguirla de un cambio de HTML",
+		},
+		{
+			name:        "otro error",
+			stale:       true,
+			fetchErr:    errs.ErrNotFound,
+			wantError:   true,
+			description: "los demás errores de la fuente deben propagarse",
+		},
+		{
+			name:        "sin copia de respaldo",
+			stale:       false,
+			fetchErr:    errs.ErrCircuitOpen,
+			wantError:   true,
+			description: "sin WithStale no se guarda copia y el circuito abierto se propaga",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := mocks.NewCacheStub()
+			cached := services.NewCached(cache, true, "genres", services.IsEmptySlice[dto.Genre])
+			if tc.stale {
+				cached.WithStale()
+			}
+			ctx := context.Background()
+
+			if _, err := cached.Get(ctx, cached.Key(), func(context.Context) ([]dto.Genre, error) {
+				return mocks.MockGenres(), nil
+			}); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			wantTTL, wantSets := time.Duration(0), 1
+			if tc.stale {
+				wantTTL, wantSets = 7*24*time.Hour, 2
+			}
+			if got := cache.TTLs["stale-genres"]; got != wantTTL {
+				t.Errorf("TTL de stale-genres = %v, want %v", got, wantTTL)
+			}
+			if cache.Sets != wantSets {
+				t.Errorf("escrituras = %d, want %d", cache.Sets, wantSets)
+			}
+
+			// Simula la expiración de la entrada normal.
+			if err := cache.Delete(ctx, cached.Key()); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+
+			result, err := cached.Get(ctx, cached.Key(), func(context.Context) ([]dto.Genre, error) {
+				return nil, tc.fetchErr
+			})
+			if (err != nil) != tc.wantError {
+				t.Fatalf("Get() error = %v, wantError %v (%s)", err, tc.wantError, tc.description)
+			}
+			if !tc.wantError && len(result) != len(mocks.MockGenres()) {
+				t.Errorf("Get() retornó %d géneros, want %d", len(result), len(mocks.MockGenres()))
+			}
+		})
+	}
+}
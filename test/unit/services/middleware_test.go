@@ -0,0 +1,271 @@
+// Package services contiene tests unitarios para los componentes de dominio compartidos.
+// Este archivo (middleware_test.go) verifica el encadenamiento de middlewares del scraper
+// y el middleware de caché integrado.
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/domain/errs"
+	"github.com/dst3v3n/api-anime/internal/domain/services"
+	"github.com/dst3v3n/api-anime/internal/mocks"
+	"github.com/dst3v3n/api-anime/internal/ports"
+)
+
+// recordingScraper registra su nombre en calls antes de delegar AnimeInfo.
+type recordingScraper struct {
+	ports.ScraperPort
+	name  string
+	calls *[]string
+}
+
+func (r *recordingScraper) AnimeInfo(ctx context.Context, idAnime string) (dto.AnimeInfoResponse, error) {
+	*r.calls = append(*r.calls, r.name)
+	return r.ScraperPort.AnimeInfo(ctx, idAnime)
+}
+
+// recording crea un middleware que registra su nombre en calls.
+func recording(name string, calls *[]string) ports.ScraperMiddleware {
+	return func(next ports.ScraperPort) ports.ScraperPort {
+		return &recordingScraper{ScraperPort: next, name: name, calls: calls}
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	var calls []string
+	scraper := services.Chain(&mocks.ScraperStub{},
+		recording("auditoría", &calls),
+		nil,
+		recording("métricas", &calls),
+	)
+
+	if _, err := scraper.AnimeInfo(context.Background(), "one-piece-tv"); err != nil {
+		t.Fatalf("AnimeInfo() error = %v", err)
+	}
+
+	want := []string{"auditoría", "métricas"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("orden de llamadas = %v, want %v", calls, want)
+	}
+}
+
+func TestCachingMiddleware(t *testing.T) {
+	testCases := []struct {
+		name        string
+		enabled     bool
+		wantFetches int
+		description string
+	}{
+		{
+			name:        "caché habilitado",
+			enabled:     true,
+			wantFetches: 1,
+			description: "la segunda consulta del mismo episodio debe servirse desde caché",
+		},
+		{
+			name:        "caché deshabilitado",
+			enabled:     false,
+			wantFetches: 2,
+			description: "sin caché cada consulta llega al scraper",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetches := 0
+			stub := &mocks.ScraperStub{
+				LinksFn: func(_ context.Context, idAnime string, episode uint) (dto.LinkResponse, error) {
+					fetches++
+					result := mocks.MockLinkResponse()
+					result.ID = idAnime
+					result.Episode = episode
+					return result, nil
+				},
+			}
+			cache := mocks.NewCacheStub()
+			scraper := services.Chain(stub, services.CachingMiddleware(cache, tc.enabled))
+
+			for range 2 {
+				result, err := scraper.Links(context.Background(), "one-piece-tv", 1090)
+				if err != nil {
+					t.Fatalf("Links() error = %v", err)
+				}
+				if result.Episode != 1090 || len(result.Link) == 0 {
+					t.Errorf("Links() = %+v, want episodio 1090 con enlaces", result)
+				}
+			}
+
+			if fetches != tc.wantFetches {
+				t.Errorf("consultas al scraper = %d, want %d", fetches, tc.wantFetches)
+			}
+			if exists, _ := cache.Exists(context.Background(), "links-one-piece-tv-1090"); exists != tc.enabled {
+				t.Errorf("clave links-one-piece-tv-1090 existe = %v, want %v", exists, tc.enabled)
+			}
+		})
+	}
+}
+
+func TestCachingMiddlewareBrowseKey(t *testing.T) {
+	base := dto.BrowseFilter{
+		Genres: []string{"accion", "drama"},
+		Years:  []int{2024},
+		Types:  []dto.BrowseType{dto.BrowseMovie},
+		Order:  dto.OrderRating,
+	}
+
+	testCases := []struct {
+		name        string
+		filter      dto.BrowseFilter
+		page        string
+		wantFetches int
+		description string
+	}{
+		{
+			name: "mismo filtro en otro orden",
+			filter: dto.BrowseFilter{
+				Genres: []string{"drama", "accion"},
+				Years:  []int{2024},
+				Types:  []dto.BrowseType{dto.BrowseMovie},
+				Order:  dto.OrderRating,
+			},
+			page:        "1",
+			wantFetches: 1,
+			description: "filtros equivalentes deben compartir la clave de caché",
+		},
+		{
+			name: "otro año",
+			filter: dto.BrowseFilter{
+				Genres: []string{"accion", "drama"},
+				Years:  []int{2023},
+				Types:  []dto.BrowseType{dto.BrowseMovie},
+				Order:  dto.OrderRating,
+			},
+			page:        "1",
+			wantFetches: 2,
+			description: "un filtro distinto no debe servirse desde la caché de otro",
+		},
+		{
+			name:        "otra página",
+			filter:      base,
+			page:        "2",
+			wantFetches: 2,
+			description: "cada página se cachea por separado",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fetches := 0
+			stub := &mocks.ScraperStub{
+				BrowseFn: func(context.Context, dto.BrowseFilter, string) (dto.AnimeResponse, error) {
+					fetches++
+					return mocks.MockAnimeResponse(), nil
+				},
+			}
+			scraper := services.Chain(stub, services.CachingMiddleware(mocks.NewCacheStub(), true))
+
+			if _, err := scraper.Browse(context.Background(), base, "1"); err != nil {
+				t.Fatalf("Browse() error = %v", err)
+			}
+			if _, err := scraper.Browse(context.Background(), tc.filter, tc.page); err != nil {
+				t.Fatalf("Browse() error = %v", err)
+			}
+
+			if fetches != tc.wantFetches {
+				t.Errorf("consultas al scraper = %d, want %d (%s)", fetches, tc.wantFetches, tc.description)
+			}
+		})
+	}
+}
+
+func TestCachingMiddlewareStaleListing(t *testing.T) {
+	testCases := []struct {
+		name        string
+		call        func(ctx context.Context, scraper ports.ScraperPort) (dto.AnimeResponse, error)
+		key         string
+		wantStale   bool
+		description string
+	}{
+		{
+			name: "listado completo",
+			call: func(ctx context.Context, scraper ports.ScraperPort) (dto.AnimeResponse, error) {
+				return scraper.Search(ctx)
+			},
+			key:         "search-anime-all",
+			wantStale:   true,
+			description: "el listado completo debe servirse desde la copia de respaldo con el circuito abierto",
+		},
+		{
+			name: "catálogo sin filtros",
+			call: func(ctx context.Context, scraper ports.ScraperPort) (dto.AnimeResponse, error) {
+				return scraper.Browse(ctx, dto.BrowseFilter{}, "1")
+			},
+			key:         "browse-page-1",
+			wantStale:   true,
+			description: "las páginas que recorre Crawl deben servirse desde la copia de respaldo",
+		},
+		{
+			name: "búsqueda por nombre",
+			call: func(ctx context.Context, scraper ports.ScraperPort) (dto.AnimeResponse, error) {
+				return scraper.SearchAnime(ctx, "naruto", "1")
+			},
+			key:         "search-anime-naruto-page-1",
+			wantStale:   false,
+			description: "las búsquedas por nombre no guardan copia de respaldo",
+		},
+		{
+			name: "catálogo filtrado",
+			call: func(ctx context.Context, scraper ports.ScraperPort) (dto.AnimeResponse, error) {
+				return scraper.Browse(ctx, dto.BrowseFilter{Years: []int{2024}}, "1")
+			},
+			key:         "browse-year-2024-page-1",
+			wantStale:   false,
+			description: "las páginas del catálogo filtrado no guardan copia de respaldo",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fetchErr error
+			fetch := func(context.Context) (dto.AnimeResponse, error) {
+				if fetchErr != nil {
+					return dto.AnimeResponse{}, fetchErr
+				}
+				return mocks.MockAnimeResponse(), nil
+			}
+			stub := &mocks.ScraperStub{
+				SearchFn:      fetch,
+				SearchAnimeFn: func(ctx context.Context, _ string, _ string) (dto.AnimeResponse, error) { return fetch(ctx) },
+				BrowseFn:      func(ctx context.Context, _ dto.BrowseFilter, _ string) (dto.AnimeResponse, error) { return fetch(ctx) },
+			}
+			cache := mocks.NewCacheStub()
+			scraper := services.Chain(stub, services.CachingMiddleware(cache, true))
+			ctx := context.Background()
+
+			if _, err := tc.call(ctx, scraper); err != nil {
+				t.Fatalf("primera consulta error = %v", err)
+			}
+			if exists, _ := cache.Exists(ctx, "stale-"+tc.key); exists != tc.wantStale {
+				t.Errorf("clave stale-%s existe = %v, want %v (%s)", tc.key, exists, tc.wantStale, tc.description)
+			}
+
+			// Simula la expiración de la entrada normal con el circuito abierto.
+			if err := cache.Delete(ctx, tc.key); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			fetchErr = errs.ErrCircuitOpen
+
+			result, err := tc.call(ctx, scraper)
+			if tc.wantStale && (err != nil || len(result.Animes) == 0) {
+				t.Errorf("consulta con circuito abierto = %d animes, error %v (%s)", len(result.Animes), err, tc.description)
+			}
+			if !tc.wantStale && !errors.Is(err, errs.ErrCircuitOpen) {
+				t.Errorf("consulta con circuito abierto error = %v, want %v (%s)", err, errs.ErrCircuitOpen, tc.description)
+			}
+		})
+	}
+}
@@ -4,7 +4,10 @@
 // Esto mantiene la abstracción y facilita cambios internos sin afectar la API pública.
 package types
 
-import "github.com/dst3v3n/api-anime/internal/domain/dto"
+import (
+	"github.com/dst3v3n/api-anime/internal/domain/dto"
+	"github.com/dst3v3n/api-anime/internal/ports"
+)
 
 // AnimeResponse contiene el resultado de una búsqueda de animes con información de paginación.
 // Incluye la lista de animes encontrados y el total de páginas disponibles.
@@ -13,14 +16,173 @@ type AnimeResponse = dto.AnimeResponse
 // AnimeStruct contiene la información básica de un anime (título, sinopsis, puntuación, etc.).
 type AnimeStruct = dto.AnimeStruct
 
+// AnimeRef es una referencia mínima a un anime (ID, título y tipo), usada por OnAir.
+type AnimeRef = dto.AnimeRef
+
 // AnimeInfoResponse contiene información detallada completa de un anime específico.
 // Extiende AnimeStruct con géneros, estado, episodios, animes relacionados y próximo episodio.
 type AnimeInfoResponse = dto.AnimeInfoResponse
 
+// Episode contiene el número, el ID, la ruta y la miniatura de un episodio de AnimeInfoResponse.
+type Episode = dto.Episode
+
+// AnimeRelated contiene un anime relacionado con su tipo de relación.
+type AnimeRelated = dto.AnimeRelated
+
+// RelationType clasifica la relación entre un anime y un anime relacionado.
+type RelationType = dto.RelationType
+
+// Tipos de relación entre animes.
+const (
+	RelationSequel      = dto.RelationSequel
+	RelationPrequel     = dto.RelationPrequel
+	RelationSideStory   = dto.RelationSideStory
+	RelationParentStory = dto.RelationParentStory
+	RelationAlternative = dto.RelationAlternative
+	RelationSpinOff     = dto.RelationSpinOff
+	RelationOther       = dto.RelationOther
+)
+
+// Franchise contiene los animes de una franquicia en orden de visualización sugerido.
+type Franchise = dto.Franchise
+
+// FranchiseEntry representa un anime dentro de una franquicia.
+type FranchiseEntry = dto.FranchiseEntry
+
+// FranchiseRelation representa una relación entre dos animes de una franquicia.
+type FranchiseRelation = dto.FranchiseRelation
+
 // LinkResponse contiene los enlaces de reproducción/descarga disponibles para un episodio.
 // Incluye múltiples servidores de video con sus URLs y códigos de embed.
 type LinkResponse = dto.LinkResponse
 
+// Language identifica el idioma de un enlace de reproducción.
+type Language = dto.Language
+
+// Idiomas conocidos de los enlaces de reproducción.
+const (
+	LanguageSUB = dto.LanguageSUB
+	LanguageLAT = dto.LanguageLAT
+)
+
+// LinkSource representa un servidor de video de un episodio con su idioma.
+type LinkSource = dto.LinkSource
+
+// DownloadSource representa un enlace de descarga directa de la tabla de descargas de un episodio.
+type DownloadSource = dto.DownloadSource
+
 // EpisodeListResponse contiene información resumida de un episodio en un listado.
 // Se utiliza para mostrar episodios recientes sin toda la información completa.
 type EpisodeListResponse = dto.EpisodeListResponse
+
+// HomeSnapshot contiene todas las secciones de la página principal obtenidas en una sola petición.
+type HomeSnapshot = dto.HomeSnapshot
+
+// Schedule contiene el calendario de emisión de una semana agrupado por día.
+type Schedule = dto.Schedule
+
+// ScheduleDay contiene los episodios que se emiten en un día del calendario.
+type ScheduleDay = dto.ScheduleDay
+
+// ScheduleEntry representa la emisión de un episodio en el calendario.
+type ScheduleEntry = dto.ScheduleEntry
+
+// BrowseFilter contiene los filtros del catálogo usados por Browse.
+type BrowseFilter = dto.BrowseFilter
+
+// BrowseType es el tipo de contenido aceptado por BrowseFilter.
+type BrowseType = dto.BrowseType
+
+// BrowseStatus es el estado de emisión aceptado por BrowseFilter.
+type BrowseStatus = dto.BrowseStatus
+
+// BrowseOrder es el criterio de ordenamiento aceptado por BrowseFilter.
+type BrowseOrder = dto.BrowseOrder
+
+// Valores aceptados por BrowseFilter.
+const (
+	BrowseTV      = dto.BrowseTV
+	BrowseMovie   = dto.BrowseMovie
+	BrowseSpecial = dto.BrowseSpecial
+	BrowseOVA     = dto.BrowseOVA
+
+	BrowseEmision      = dto.BrowseEmision
+	BrowseFinalizado   = dto.BrowseFinalizado
+	BrowseProximamente = dto.BrowseProximamente
+
+	OrderDefault = dto.OrderDefault
+	OrderUpdated = dto.OrderUpdated
+	OrderAdded   = dto.OrderAdded
+	OrderTitle   = dto.OrderTitle
+	OrderRating  = dto.OrderRating
+)
+
+// Genre contiene el slug y el nombre visible de un género del catálogo.
+type Genre = dto.Genre
+
+// CrawlOptions configura un rastreo del catálogo con Crawl.
+type CrawlOptions = dto.CrawlOptions
+
+// CrawlProgress describe un paso completado del rastreo del catálogo.
+type CrawlProgress = dto.CrawlProgress
+
+// CrawlCheckpoint contiene el progreso persistido de un rastreo del catálogo.
+type CrawlCheckpoint = dto.CrawlCheckpoint
+
+// CrawlPhase identifica la fase de un rastreo del catálogo.
+type CrawlPhase = dto.CrawlPhase
+
+// Fases del rastreo del catálogo.
+const (
+	CrawlBrowse = dto.CrawlBrowse
+	CrawlInfo   = dto.CrawlInfo
+	CrawlDone   = dto.CrawlDone
+)
+
+// CircuitState es el estado del circuit breaker del scraper, retornado por AnimeFlv.CircuitState.
+type CircuitState = dto.CircuitState
+
+// Estados del circuit breaker del scraper.
+const (
+	CircuitClosed   = dto.CircuitClosed
+	CircuitOpen     = dto.CircuitOpen
+	CircuitHalfOpen = dto.CircuitHalfOpen
+)
+
+// RequestClass es una clase de operación del scraper con su propio límite de peticiones.
+type RequestClass = dto.RequestClass
+
+// Clases de operación del scraper.
+const (
+	RequestBrowse  = dto.RequestBrowse
+	RequestInfo    = dto.RequestInfo
+	RequestEpisode = dto.RequestEpisode
+	RequestHome    = dto.RequestHome
+)
+
+// RateLimitState es el estado del límite de peticiones de una clase, retornado por AnimeFlv.RateLimits.
+type RateLimitState = dto.RateLimitState
+
+// BatchOptions configura las operaciones por lotes como AnimeInfoMany.
+type BatchOptions = dto.BatchOptions
+
+// CachePort es el contrato que debe cumplir un caché inyectado con anime.WithCache.
+type CachePort = ports.CachePort
+
+// CacheTTLPort es la capacidad opcional de un CachePort para guardar valores con TTL propia.
+type CacheTTLPort = ports.CacheTTLPort
+
+// CheckpointStore es el contrato de los almacenes de checkpoints usados por Crawl.
+type CheckpointStore = ports.CheckpointStore
+
+// ScraperPort es el contrato que debe cumplir un scraper inyectado con anime.WithScraper.
+type ScraperPort = ports.ScraperPort
+
+// CircuitStatePort es la capacidad opcional de un ScraperPort para exponer el estado de su circuit breaker.
+type CircuitStatePort = ports.CircuitStatePort
+
+// RateLimitPort es la capacidad opcional de un ScraperPort para exponer el estado de sus límites de peticiones.
+type RateLimitPort = ports.RateLimitPort
+
+// ScraperMiddleware decora un ScraperPort; se registra con anime.WithMiddleware.
+type ScraperMiddleware = ports.ScraperMiddleware